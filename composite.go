@@ -0,0 +1,111 @@
+package decimal
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// tryWrapPolicyStructScanPlan wraps a pointer to a struct with fields tagged with the pgxdecimal struct tag so composite
+// values scanned into it apply the same policies as ScanStructByName. The fields are matched to the composite fields by
+// position like pgx matches other structs.
+func tryWrapPolicyStructScanPlan(target interface{}) (plan pgtype.WrappedScanPlanNextSetter, nextDst interface{}, ok bool) {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr || !hasScanPolicyFields(targetType.Elem()) {
+		return nil, nil, false
+	}
+
+	targetValue := reflect.ValueOf(target)
+	if targetValue.IsNil() {
+		targetValue = reflect.New(targetType.Elem())
+	}
+
+	s, err := newPolicyStructScanner(targetValue.Interface())
+	if err != nil {
+		// Let the plan fail when it is used.
+		s = &policyStructScanner{s: target}
+	}
+
+	return &wrapPolicyStructScanPlan{}, s, true
+}
+
+// hasScanPolicyFields reports whether t is a struct with an exported field with the pgxdecimal struct tag.
+func hasScanPolicyFields(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup(structTagKey); ok && sf.IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+type wrapPolicyStructScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *wrapPolicyStructScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapPolicyStructScanPlan) Scan(src []byte, dst interface{}) error {
+	s, err := newPolicyStructScanner(dst)
+	if err != nil {
+		return err
+	}
+
+	return plan.next.Scan(src, s)
+}
+
+// policyStructScanner implements pgtype.CompositeIndexScanner for a pointer to a struct. Fields with the pgxdecimal
+// struct tag are scanned through a ScanAdapter.
+type policyStructScanner struct {
+	s       interface{}
+	targets []interface{}
+}
+
+func newPolicyStructScanner(dst interface{}) (*policyStructScanner, error) {
+	structValue := reflect.ValueOf(dst).Elem()
+	structType := structValue.Type()
+
+	s := &policyStructScanner{s: dst}
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		target := structValue.Field(i).Addr().Interface()
+		if policyTag, ok := sf.Tag.Lookup(structTagKey); ok {
+			policy, err := parseScanPolicyTag(policyTag)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+
+			target, err = newPolicyScanner(target, policy)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+		}
+
+		s.targets = append(s.targets, target)
+	}
+
+	return s, nil
+}
+
+func (s *policyStructScanner) ScanNull() error {
+	return fmt.Errorf("cannot scan NULL into %T", s.s)
+}
+
+func (s *policyStructScanner) ScanIndex(i int) interface{} {
+	if i >= len(s.targets) {
+		return fmt.Errorf("%T only has %d public fields - %d is out of bounds", s.s, len(s.targets), i)
+	}
+
+	return s.targets[i]
+}
@@ -0,0 +1,152 @@
+package decimal_test
+
+import (
+	"context"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+type lineItem struct {
+	Sku   string
+	Qty   decimal.Decimal
+	Price decimal.NullDecimal
+}
+
+type roundedLineItem struct {
+	Sku   string
+	Qty   decimal.Decimal     `pgxdecimal:"scale=0,round=floor"`
+	Price decimal.NullDecimal `pgxdecimal:"nan=null"`
+}
+
+func registerLineItem(m *pgtype.Map) {
+	text, _ := m.TypeForOID(pgtype.TextOID)
+	numeric, _ := m.TypeForOID(pgtype.NumericOID)
+
+	dt := &pgtype.Type{Name: "line_item", OID: 100000, Codec: &pgtype.CompositeCodec{Fields: []pgtype.CompositeCodecField{
+		{Name: "sku", Type: text},
+		{Name: "qty", Type: numeric},
+		{Name: "price", Type: numeric},
+	}}}
+	m.RegisterType(dt)
+	m.RegisterType(&pgtype.Type{Name: "_line_item", OID: 100001, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+}
+
+func TestCompositeFields(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+	registerLineItem(m)
+
+	items := []lineItem{
+		{Sku: "a", Qty: decimal.RequireFromString("1.50"), Price: decimal.NullDecimal{Decimal: decimal.RequireFromString("-12.25"), Valid: true}},
+		{Sku: "b", Qty: decimal.Zero},
+	}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(100000, format, items[0], nil)
+		require.NoError(t, err)
+
+		var item lineItem
+		err = m.Scan(100000, format, buf, &item)
+		require.NoError(t, err)
+		require.Equal(t, "a", item.Sku)
+		require.True(t, item.Qty.Equal(items[0].Qty))
+		require.True(t, item.Price.Valid && item.Price.Decimal.Equal(items[0].Price.Decimal))
+
+		buf, err = m.Encode(100001, format, items, nil)
+		require.NoError(t, err)
+
+		var scanned []lineItem
+		err = m.Scan(100001, format, buf, &scanned)
+		require.NoError(t, err)
+		require.Len(t, scanned, 2)
+		require.True(t, scanned[0].Qty.Equal(items[0].Qty))
+		require.False(t, scanned[1].Price.Valid)
+	}
+
+	// Numerics in text format composites are written without an exponent.
+	buf, err := m.Encode(100000, pgtype.TextFormatCode, items[0], nil)
+	require.NoError(t, err)
+	require.Equal(t, "(a,1.50,-12.25)", string(buf))
+}
+
+func TestCompositeFieldPolicies(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+	registerLineItem(m)
+
+	var item lineItem
+	err := m.Scan(100000, pgtype.TextFormatCode, []byte("(a,1.5,NaN)"), &item)
+	require.EqualError(t, err, "cannot scan NaN into *decimal.NullDecimal")
+
+	var rounded roundedLineItem
+	err = m.Scan(100000, pgtype.TextFormatCode, []byte("(a,1.5,NaN)"), &rounded)
+	require.NoError(t, err)
+	require.Equal(t, "1", rounded.Qty.String())
+	require.False(t, rounded.Price.Valid)
+
+	var roundedItems []roundedLineItem
+	err = m.Scan(100001, pgtype.TextFormatCode, []byte(`{"(a,-1.5,NaN)","(b,2,3)"}`), &roundedItems)
+	require.NoError(t, err)
+	require.Len(t, roundedItems, 2)
+	require.Equal(t, "-2", roundedItems[0].Qty.String())
+	require.False(t, roundedItems[0].Price.Valid)
+	require.Equal(t, "3", roundedItems[1].Price.Decimal.String())
+
+	var invalid struct {
+		Sku   string
+		Qty   decimal.Decimal `pgxdecimal:"nan=maybe"`
+		Price decimal.Decimal
+	}
+	err = m.Scan(100000, pgtype.TextFormatCode, []byte("(a,1,2)"), &invalid)
+	require.EqualError(t, err, `field Qty: invalid nan policy "maybe"`)
+}
+
+func TestCompositeLoadType(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, `create type pgxdecimal_line_item as (sku text, qty numeric, price numeric)`)
+		require.NoError(t, err)
+
+		for _, name := range []string{"pgxdecimal_line_item", "_pgxdecimal_line_item"} {
+			dt, err := conn.LoadType(ctx, name)
+			require.NoError(t, err)
+			conn.TypeMap().RegisterType(dt)
+		}
+
+		items := []lineItem{
+			{Sku: "a", Qty: decimal.RequireFromString("1.50"), Price: decimal.NullDecimal{Decimal: decimal.RequireFromString("-12.25"), Valid: true}},
+			{Sku: "b", Qty: decimal.RequireFromString("1e30")},
+		}
+
+		var item lineItem
+		err = conn.QueryRow(ctx, `select $1::pgxdecimal_line_item`, items[0]).Scan(&item)
+		require.NoError(t, err)
+		require.Equal(t, "a", item.Sku)
+		require.True(t, item.Qty.Equal(items[0].Qty))
+		require.True(t, item.Price.Valid && item.Price.Decimal.Equal(items[0].Price.Decimal))
+
+		var scanned []lineItem
+		err = conn.QueryRow(ctx, `select $1::pgxdecimal_line_item[]`, items).Scan(&scanned)
+		require.NoError(t, err)
+		require.Len(t, scanned, 2)
+		require.True(t, scanned[1].Qty.Equal(items[1].Qty))
+		require.False(t, scanned[1].Price.Valid)
+
+		err = conn.QueryRow(ctx, `select row('a', 1, 'NaN')::pgxdecimal_line_item`).Scan(&item)
+		require.EqualError(t, err, "can't scan into dest[0]: cannot scan NaN into *decimal.NullDecimal")
+
+		var rounded roundedLineItem
+		err = conn.QueryRow(ctx, `select row('a', 2.75, 'NaN')::pgxdecimal_line_item`).Scan(&rounded)
+		require.NoError(t, err)
+		require.Equal(t, "2", rounded.Qty.String())
+		require.False(t, rounded.Price.Valid)
+	})
+}
@@ -1,6 +1,13 @@
+// Package decimal integrates github.com/shopspring/decimal with pgx. Call Register or RegisterWithOptions on the type
+// map of each connection.
+//
+// NumericCodec does not override DecodeDatabaseSQLValue. The stdlib package of the pgx version this package is built
+// against never calls it: numeric columns read through database/sql are always returned as strings, which
+// decimal.Decimal, decimal.NullDecimal, Decimal and NullDecimal scan without loss of precision.
 package decimal
 
 import (
+	"database/sql"
 	"fmt"
 	"math"
 	"reflect"
@@ -68,18 +75,7 @@ func (d *Decimal) ScanInt64(v pgtype.Int8) error {
 }
 
 func (d Decimal) Int64Value() (pgtype.Int8, error) {
-	dd := decimal.Decimal(d)
-
-	if !dd.IsInteger() {
-		return pgtype.Int8{}, fmt.Errorf("cannot convert %v to int64", dd)
-	}
-
-	bi := dd.BigInt()
-	if !bi.IsInt64() {
-		return pgtype.Int8{}, fmt.Errorf("cannot convert %v to int64", dd)
-	}
-
-	return pgtype.Int8{Int64: bi.Int64(), Valid: true}, nil
+	return int64Value(decimal.Decimal(d))
 }
 
 type NullDecimal decimal.NullDecimal
@@ -156,84 +152,63 @@ func (d NullDecimal) Int64Value() (pgtype.Int8, error) {
 		return pgtype.Int8{}, nil
 	}
 
-	dd := decimal.NullDecimal(d).Decimal
-
-	if !dd.IsInteger() {
-		return pgtype.Int8{}, fmt.Errorf("cannot convert %v to int64", dd)
-	}
-
-	bi := dd.BigInt()
-	if !bi.IsInt64() {
-		return pgtype.Int8{}, fmt.Errorf("cannot convert %v to int64", dd)
-	}
-
-	return pgtype.Int8{Int64: bi.Int64(), Valid: true}, nil
+	return int64Value(d.Decimal)
 }
 
-func TryWrapNumericEncodePlan(value interface{}) (plan pgtype.WrappedEncodePlanNextSetter, nextValue interface{}, ok bool) {
-	switch value := value.(type) {
-	case decimal.Decimal:
-		return &wrapDecimalEncodePlan{}, Decimal(value), true
-	case decimal.NullDecimal:
-		return &wrapNullDecimalEncodePlan{}, NullDecimal(value), true
-	}
-
-	return nil, nil, false
-}
-
-type wrapDecimalEncodePlan struct {
-	next pgtype.EncodePlan
-}
+// defaultOptions are the options used by Register.
+var defaultOptions = &Options{}
 
-func (plan *wrapDecimalEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
-
-func (plan *wrapDecimalEncodePlan) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
-	return plan.next.Encode(Decimal(value.(decimal.Decimal)), buf)
+func TryWrapNumericEncodePlan(value interface{}) (plan pgtype.WrappedEncodePlanNextSetter, nextValue interface{}, ok bool) {
+	return defaultOptions.tryWrapNumericEncodePlan(value)
 }
 
-type wrapNullDecimalEncodePlan struct {
-	next pgtype.EncodePlan
+func TryWrapNumericScanPlan(target interface{}) (plan pgtype.WrappedScanPlanNextSetter, nextDst interface{}, ok bool) {
+	return defaultOptions.tryWrapNumericScanPlan(target)
 }
 
-func (plan *wrapNullDecimalEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
-
-func (plan *wrapNullDecimalEncodePlan) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
-	return plan.next.Encode(NullDecimal(value.(decimal.NullDecimal)), buf)
+type NumericCodec struct {
+	pgtype.NumericCodec
 }
 
-func TryWrapNumericScanPlan(target interface{}) (plan pgtype.WrappedScanPlanNextSetter, nextDst interface{}, ok bool) {
-	switch target := target.(type) {
-	case *decimal.Decimal:
-		return &wrapDecimalScanPlan{}, (*Decimal)(target), true
-	case *decimal.NullDecimal:
-		return &wrapNullDecimalScanPlan{}, (*NullDecimal)(target), true
+// PlanEncode encodes pgtype.NumericValuers in the text format as plain decimal numbers, e.g. 12.50. pgtype.NumericCodec
+// uses exponent notation, e.g. 1250e-2, which PostgreSQL accepts but pgx and the input functions of most numeric-like
+// types do not, so values in text format composites and arrays would not round trip.
+func (c NumericCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	if _, ok := value.(pgtype.NumericValuer); ok && format == pgtype.TextFormatCode {
+		return encodePlanNumericValuerToPlainText{}
 	}
 
-	return nil, nil, false
-}
-
-type wrapDecimalScanPlan struct {
-	next pgtype.ScanPlan
+	return c.NumericCodec.PlanEncode(m, oid, format, value)
 }
 
-func (plan *wrapDecimalScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+// encodePlanNumericValuerToPlainText encodes a pgtype.NumericValuer in the text format without an exponent.
+type encodePlanNumericValuerToPlainText struct{}
 
-func (plan *wrapDecimalScanPlan) Scan(src []byte, dst interface{}) error {
-	return plan.next.Scan(src, (*Decimal)(dst.(*decimal.Decimal)))
-}
+func (encodePlanNumericValuerToPlainText) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	n, err := value.(pgtype.NumericValuer).NumericValue()
+	if err != nil {
+		return nil, err
+	}
 
-type wrapNullDecimalScanPlan struct {
-	next pgtype.ScanPlan
-}
+	if !n.Valid {
+		return nil, nil
+	}
 
-func (plan *wrapNullDecimalScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+	switch {
+	case n.NaN:
+		return append(buf, "NaN"...), nil
+	case n.InfinityModifier == pgtype.Infinity:
+		return append(buf, "Infinity"...), nil
+	case n.InfinityModifier == pgtype.NegativeInfinity:
+		return append(buf, "-Infinity"...), nil
+	}
 
-func (plan *wrapNullDecimalScanPlan) Scan(src []byte, dst interface{}) error {
-	return plan.next.Scan(src, (*NullDecimal)(dst.(*decimal.NullDecimal)))
-}
+	d := decimal.NewFromBigInt(n.Int, n.Exp)
+	if n.Exp < 0 {
+		return append(buf, d.StringFixed(-n.Exp)...), nil
+	}
 
-type NumericCodec struct {
-	pgtype.NumericCodec
+	return append(buf, d.String()...), nil
 }
 
 func (NumericCodec) DecodeValue(tm *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
@@ -256,9 +231,26 @@ func (NumericCodec) DecodeValue(tm *pgtype.Map, oid uint32, format int16, src []
 }
 
 // Register registers the shopspring/decimal integration with a pgtype.ConnInfo.
+// numeric values are decoded as decimal.Decimal, including the fields of records such as those created with row().
 func Register(m *pgtype.Map) {
-	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapNumericEncodePlan}, m.TryWrapEncodePlanFuncs...)
-	m.TryWrapScanPlanFuncs = append([]pgtype.TryWrapScanPlanFunc{TryWrapNumericScanPlan}, m.TryWrapScanPlanFuncs...)
+	RegisterWithOptions(m, Options{})
+}
+
+// RegisterWithOptions registers the shopspring/decimal integration with a pgtype.ConnInfo configured by opts. The
+// options apply to decimal.Decimal and decimal.NullDecimal values. Decimal and NullDecimal always use the default
+// behavior.
+func RegisterWithOptions(m *pgtype.Map, opts Options) {
+	o := &opts
+
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{o.tryWrapNumericEncodePlan}, m.TryWrapEncodePlanFuncs...)
+	m.TryWrapScanPlanFuncs = append([]pgtype.TryWrapScanPlanFunc{o.tryWrapNumericScanPlan, tryWrapPolicyStructScanPlan}, m.TryWrapScanPlanFuncs...)
+
+	registerIntRangeCodecs(m)
+	registerFloat4Codec(m, o)
+	registerFloat8Codec(m, o)
+	registerTextCodecs(m, o)
+	registerJSONCodecs(m, o)
+	registerNumrangeCodec(m)
 
 	m.RegisterType(&pgtype.Type{
 		Name:  "numeric",
@@ -266,31 +258,39 @@ func Register(m *pgtype.Map) {
 		Codec: NumericCodec{},
 	})
 
-	registerDefaultPgTypeVariants := func(name, arrayName string, value interface{}) {
-		// T
-		m.RegisterDefaultPgType(value, name)
+	registerDefaultPgTypeVariants(m, "numeric", "_numeric", decimal.Decimal{})
+	registerDefaultPgTypeVariants(m, "numeric", "_numeric", decimal.NullDecimal{})
+	registerDefaultPgTypeVariants(m, "numeric", "_numeric", sql.Null[decimal.Decimal]{})
+	registerDefaultPgTypeVariants(m, "numeric", "_numeric", Decimal{})
+	registerDefaultPgTypeVariants(m, "numeric", "_numeric", NullDecimal{})
+	// Only Fixed and *Fixed are registered. The elements of a slice scanned from an array would have a zero Scale and
+	// be rounded to integers.
+	m.RegisterDefaultPgType(Fixed{}, "numeric")
+	m.RegisterDefaultPgType(&Fixed{}, "numeric")
+	registerDefaultPgTypeVariants(m, "numrange", "_numrange", Range{})
+	registerDefaultPgTypeVariants(m, "numrange", "_numrange", NullRange{})
+}
 
-		// *T
-		valueType := reflect.TypeOf(value)
-		m.RegisterDefaultPgType(reflect.New(valueType).Interface(), name)
+// registerDefaultPgTypeVariants registers value and its pointer and slice variants as name and arrayName.
+func registerDefaultPgTypeVariants(m *pgtype.Map, name, arrayName string, value interface{}) {
+	// T
+	m.RegisterDefaultPgType(value, name)
 
-		// []T
-		sliceType := reflect.SliceOf(valueType)
-		m.RegisterDefaultPgType(reflect.MakeSlice(sliceType, 0, 0).Interface(), arrayName)
+	// *T
+	valueType := reflect.TypeOf(value)
+	m.RegisterDefaultPgType(reflect.New(valueType).Interface(), name)
 
-		// *[]T
-		m.RegisterDefaultPgType(reflect.New(sliceType).Interface(), arrayName)
+	// []T
+	sliceType := reflect.SliceOf(valueType)
+	m.RegisterDefaultPgType(reflect.MakeSlice(sliceType, 0, 0).Interface(), arrayName)
 
-		// []*T
-		sliceOfPointerType := reflect.SliceOf(reflect.TypeOf(reflect.New(valueType).Interface()))
-		m.RegisterDefaultPgType(reflect.MakeSlice(sliceOfPointerType, 0, 0).Interface(), arrayName)
+	// *[]T
+	m.RegisterDefaultPgType(reflect.New(sliceType).Interface(), arrayName)
 
-		// *[]*T
-		m.RegisterDefaultPgType(reflect.New(sliceOfPointerType).Interface(), arrayName)
-	}
+	// []*T
+	sliceOfPointerType := reflect.SliceOf(reflect.TypeOf(reflect.New(valueType).Interface()))
+	m.RegisterDefaultPgType(reflect.MakeSlice(sliceOfPointerType, 0, 0).Interface(), arrayName)
 
-	registerDefaultPgTypeVariants("numeric", "_numeric", decimal.Decimal{})
-	registerDefaultPgTypeVariants("numeric", "_numeric", decimal.NullDecimal{})
-	registerDefaultPgTypeVariants("numeric", "_numeric", Decimal{})
-	registerDefaultPgTypeVariants("numeric", "_numeric", NullDecimal{})
+	// *[]*T
+	m.RegisterDefaultPgType(reflect.New(sliceOfPointerType).Interface(), arrayName)
 }
@@ -3,6 +3,7 @@ package decimal_test
 import (
 	"context"
 	"math"
+	"strconv"
 	"testing"
 
 	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
@@ -280,3 +281,160 @@ func TestValueRoundTripInt8(t *testing.T) {
 		},
 	})
 }
+
+func skipPostgreSQLVersionLessThan(t testing.TB, conn *pgx.Conn, minVersion int64) {
+	serverVersion, err := strconv.ParseInt(conn.PgConn().ParameterStatus("server_version_num"), 10, 64)
+	if err != nil {
+		t.Fatalf("unable to parse server_version_num: %v", err)
+	}
+
+	if serverVersion < minVersion {
+		t.Skipf("test requires PostgreSQL server_version_num >= %d", minVersion)
+	}
+}
+
+func TestValueRoundTripPositiveExponent(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "numeric", []pgxtest.ValueRoundTripTest{
+		{
+			Param:  decimal.New(12, 3),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("12000")),
+		},
+		{
+			Param:  decimal.New(-12345, 7),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("-123450000000")),
+		},
+		{
+			Param:  decimal.New(0, 5),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.Zero),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.New(1, 20), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("100000000000000000000"), Valid: true}),
+		},
+	})
+}
+
+func TestValueRoundTripNegativeScale(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		skipPostgreSQLVersionLessThan(t, conn, 150000)
+	})
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "numeric(5,-3)", []pgxtest.ValueRoundTripTest{
+		{
+			Param:  decimal.RequireFromString("12345"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("12000")),
+		},
+		{
+			Param:  decimal.RequireFromString("-12500.5"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("-13000")),
+		},
+		{
+			Param:  decimal.New(99, 6),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("99000000")),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("499"), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.Zero, Valid: true}),
+		},
+	})
+}
+
+func TestArrayNegativeScale(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		skipPostgreSQLVersionLessThan(t, conn, 150000)
+
+		inputSlice := []decimal.Decimal{
+			decimal.RequireFromString("12345"),
+			decimal.New(7, 4),
+			decimal.RequireFromString("-1500"),
+		}
+
+		var outputSlice []decimal.Decimal
+		err := conn.QueryRow(context.Background(), `select $1::numeric(5,-3)[]`, inputSlice).Scan(&outputSlice)
+		require.NoError(t, err)
+
+		require.Len(t, outputSlice, len(inputSlice))
+		typmod := pgxdecimal.NumericTypmod{Precision: 5, Scale: -3}
+		for i := 0; i < len(inputSlice); i++ {
+			expected, err := typmod.Round(inputSlice[i])
+			require.NoError(t, err)
+			require.Truef(t, outputSlice[i].Equal(expected), "%d: expected %v, got %v", i, expected, outputSlice[i])
+		}
+	})
+}
+
+func TestNumericTypmodMatchesServer(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		skipPostgreSQLVersionLessThan(t, conn, 150000)
+
+		rows, err := conn.Query(context.Background(), `select 1::numeric(5,-3), 1::numeric(10,2), 0.001::numeric(3,5), 1::numeric`)
+		require.NoError(t, err)
+		fieldDescriptions := rows.FieldDescriptions()
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		expected := []pgxdecimal.NumericTypmod{{Precision: 5, Scale: -3}, {Precision: 10, Scale: 2}, {Precision: 3, Scale: 5}}
+		for i, e := range expected {
+			typmod, ok := pgxdecimal.ParseNumericTypmod(fieldDescriptions[i].TypeModifier)
+			require.True(t, ok)
+			require.Equal(t, e, typmod)
+		}
+
+		_, ok := pgxdecimal.ParseNumericTypmod(fieldDescriptions[3].TypeModifier)
+		require.False(t, ok)
+	})
+}
+
+func TestNumericTypmod(t *testing.T) {
+	for i, tt := range []struct {
+		typmod pgxdecimal.NumericTypmod
+	}{
+		{pgxdecimal.NumericTypmod{Precision: 10, Scale: 2}},
+		{pgxdecimal.NumericTypmod{Precision: 5, Scale: -3}},
+		{pgxdecimal.NumericTypmod{Precision: 1000, Scale: -1000}},
+		{pgxdecimal.NumericTypmod{Precision: 3, Scale: 1000}},
+	} {
+		typmod, ok := pgxdecimal.ParseNumericTypmod(tt.typmod.Typmod())
+		require.Truef(t, ok, "%d", i)
+		require.Equalf(t, tt.typmod, typmod, "%d", i)
+	}
+
+	_, ok := pgxdecimal.ParseNumericTypmod(-1)
+	require.False(t, ok)
+}
+
+func TestNumericTypmodRound(t *testing.T) {
+	for i, tt := range []struct {
+		typmod   pgxdecimal.NumericTypmod
+		value    string
+		expected string
+		overflow bool
+	}{
+		{typmod: pgxdecimal.NumericTypmod{Precision: 10, Scale: 2}, value: "1.005", expected: "1.01"},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 10, Scale: 2}, value: "-1.005", expected: "-1.01"},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 3, Scale: 2}, value: "9.995", overflow: true},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 5, Scale: -3}, value: "12345", expected: "12000"},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 5, Scale: -3}, value: "99499999", expected: "99500000"},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 5, Scale: -3}, value: "99999500", overflow: true},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 5, Scale: -3}, value: "499", expected: "0"},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 3, Scale: 5}, value: "0.00999", expected: "0.00999"},
+		{typmod: pgxdecimal.NumericTypmod{Precision: 3, Scale: 5}, value: "0.01", overflow: true},
+	} {
+		rounded, err := tt.typmod.Round(decimal.RequireFromString(tt.value))
+		if tt.overflow {
+			require.Errorf(t, err, "%d", i)
+			continue
+		}
+
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, rounded.Equal(decimal.RequireFromString(tt.expected)), "%d: expected %v, got %v", i, tt.expected, rounded)
+	}
+}
@@ -0,0 +1,282 @@
+package decimal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// numericDomainsCTE selects the domains whose base type is numeric, directly or through other domains, and their
+// effective type modifiers.
+const numericDomainsCTE = `with recursive numeric_domains(oid, typmod) as (
+  select oid, typtypmod
+  from pg_type
+  where typtype = 'd' and typbasetype = 'numeric'::regtype
+  union all
+  select t.oid, case when t.typtypmod >= 0 then t.typtypmod else d.typmod end
+  from pg_type t
+    join numeric_domains d on d.oid = t.typbasetype
+  where t.typtype = 'd'
+)
+`
+
+const loadNumericDomainsSQL = numericDomainsCTE + `select d.oid::regtype::text, d.oid, d.typmod, coalesce(a.oid::regtype::text, ''), coalesce(a.oid, 0)
+from numeric_domains d
+  join pg_type t on t.oid = d.oid
+  join pg_namespace n on n.oid = t.typnamespace
+  left join pg_type a on a.oid = t.typarray
+where cardinality($1::text[]) = 0 or n.nspname = any($1::text[])
+order by n.nspname, t.typname`
+
+const loadNumericDomainSQL = numericDomainsCTE + `select d.oid, d.typmod, coalesce(a.oid::regtype::text, ''), coalesce(a.oid, 0)
+from numeric_domains d
+  join pg_type t on t.oid = d.oid
+  left join pg_type a on a.oid = t.typarray
+where d.oid = $1::text::regtype::oid`
+
+// NumericDomain is a domain over numeric and its array type.
+type NumericDomain struct {
+	Name string
+	OID  uint32
+
+	// Typmod is only meaningful if Constrained is true. A domain over a domain inherits the base domain's typmod.
+	Typmod      NumericTypmod
+	Constrained bool
+
+	// ArrayName and ArrayOID are empty if the domain does not have an array type. ArrayName is formatted by regtype like
+	// the names of domains discovered by NumericDomains, e.g. amount[] or billing.amount[].
+	ArrayName string
+	ArrayOID  uint32
+}
+
+// RegisterDomain registers the domain name over numeric and its array type with conn's type map. e.g.
+//
+//	create domain money_amount as numeric(14,2);
+//
+//	err := pgxdecimal.RegisterDomain(ctx, conn, "money_amount")
+//
+// Values of the domain are decoded as decimal.Decimal and scanned and encoded in the same way as numeric. If the
+// domain has a precision and scale, values are rounded to the scale before they are encoded and values that do not fit
+// fail with a *NumericFieldOverflowError instead of a server error. Domains over other domains over numeric are
+// supported.
+//
+// The domain is registered as name and its array type as the array type's name as formatted by regtype (e.g.
+// money_amount[]). Use these names with pgtype.Map.RegisterDefaultPgType to send Go types as the domain. conn's type
+// map must already have been registered with Register or RegisterWithOptions.
+func RegisterDomain(ctx context.Context, conn *pgx.Conn, name string) error {
+	domain := NumericDomain{Name: name}
+	var typmod int32
+	err := conn.QueryRow(ctx, loadNumericDomainSQL, name).Scan(&domain.OID, &typmod, &domain.ArrayName, &domain.ArrayOID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s is not a domain over numeric", name)
+		}
+		return err
+	}
+	domain.Typmod, domain.Constrained = ParseNumericTypmod(typmod)
+
+	registerDomain(conn.TypeMap(), domain)
+
+	return nil
+}
+
+// registerDomain registers domain and its array type with m.
+func registerDomain(m *pgtype.Map, domain NumericDomain) {
+	var codec pgtype.Codec = NumericCodec{}
+	if domain.Constrained {
+		codec = &typmodNumericCodec{NumericCodec: NumericCodec{}, typmod: domain.Typmod}
+	}
+
+	dt := &pgtype.Type{Name: domain.Name, OID: domain.OID, Codec: codec}
+	m.RegisterType(dt)
+
+	if domain.ArrayOID != 0 {
+		m.RegisterType(&pgtype.Type{Name: domain.ArrayName, OID: domain.ArrayOID, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+	}
+}
+
+// NumericDomains discovers the domains over numeric in a database and registers them with connections to that
+// database. The domains of each database are loaded from pg_type on first use and cached until invalidated, so a pool
+// can call Register for every new connection without querying the catalog each time. Databases are identified by the
+// user, host, port and database of the connection config, so finding the cached domains does not query the server. The
+// names of the domains depend on the search_path, so connections with different search paths are cached separately.
+// The search_path is taken from the connection config unless the server reports it. PostgreSQL 18 and later report it,
+// so older servers do not detect a search_path changed with SET. Call Invalidate after such a change. It is safe for
+// concurrent use.
+//
+//	domains := pgxdecimal.NewNumericDomains("billing")
+//	poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxdecimal.Register(conn.TypeMap())
+//		return domains.Register(ctx, conn)
+//	}
+type NumericDomains struct {
+	schemas []string
+
+	mu        sync.Mutex
+	databases map[string][]NumericDomain
+}
+
+// NewNumericDomains returns a new NumericDomains with an empty cache. If schemas are given only domains in those
+// schemas are discovered.
+func NewNumericDomains(schemas ...string) *NumericDomains {
+	if schemas == nil {
+		schemas = []string{}
+	}
+
+	return &NumericDomains{schemas: schemas, databases: make(map[string][]NumericDomain)}
+}
+
+// numericDomainsKey returns the cache key for the database conn is connected to. It is computed without querying the
+// server. The database defaults to the user name like it does in PostgreSQL. The search_path is the one reported by the
+// server, which PostgreSQL 18 and later do, or else the one in the connection config.
+func numericDomainsKey(conn *pgx.Conn) string {
+	config := conn.Config()
+
+	database := config.Database
+	if database == "" {
+		database = config.User
+	}
+
+	searchPath := conn.PgConn().ParameterStatus("search_path")
+	if searchPath == "" {
+		searchPath = config.RuntimeParams["search_path"]
+	}
+
+	return fmt.Sprintf("%s@%s:%d/%s?search_path=%s", config.User, config.Host, config.Port, database, searchPath)
+}
+
+// Domains returns the domains over numeric in the database conn is connected to. The result is cached. The returned
+// slice is a copy and may be modified.
+func (d *NumericDomains) Domains(ctx context.Context, conn *pgx.Conn) ([]NumericDomain, error) {
+	domains, err := d.domains(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(domains), nil
+}
+
+// domains returns the cached domains over numeric in the database conn is connected to. The result must not be
+// modified.
+func (d *NumericDomains) domains(ctx context.Context, conn *pgx.Conn) ([]NumericDomain, error) {
+	key := numericDomainsKey(conn)
+
+	d.mu.Lock()
+	domains, ok := d.databases[key]
+	d.mu.Unlock()
+	if ok {
+		return domains, nil
+	}
+
+	rows, err := conn.Query(ctx, loadNumericDomainsSQL, d.schemas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	domains = []NumericDomain{}
+	for rows.Next() {
+		var domain NumericDomain
+		var typmod int32
+		err := rows.Scan(&domain.Name, &domain.OID, &typmod, &domain.ArrayName, &domain.ArrayOID)
+		if err != nil {
+			return nil, err
+		}
+
+		domain.Typmod, domain.Constrained = ParseNumericTypmod(typmod)
+		domains = append(domains, domain)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	d.mu.Lock()
+	d.databases[key] = domains
+	d.mu.Unlock()
+
+	return domains, nil
+}
+
+// Register registers the domains over numeric in the database conn is connected to and their array types with conn's
+// type map in the same way as RegisterDomain. Domains are registered with their names as formatted by regtype, i.e.
+// schema qualified only if the schema is not in the search_path.
+func (d *NumericDomains) Register(ctx context.Context, conn *pgx.Conn) error {
+	domains, err := d.domains(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, domain := range domains {
+		registerDomain(conn.TypeMap(), domain)
+	}
+
+	return nil
+}
+
+// Invalidate removes the database conn is connected to from the cache. It should be called after domains are created,
+// altered or dropped, or after the search_path of conn is changed with SET on a server older than PostgreSQL 18.
+// Connections to the same database with other users or search paths are cached separately and are not invalidated; use
+// InvalidateAll for those.
+func (d *NumericDomains) Invalidate(conn *pgx.Conn) {
+	d.mu.Lock()
+	delete(d.databases, numericDomainsKey(conn))
+	d.mu.Unlock()
+}
+
+// InvalidateAll empties the cache.
+func (d *NumericDomains) InvalidateAll() {
+	d.mu.Lock()
+	d.databases = make(map[string][]NumericDomain)
+	d.mu.Unlock()
+}
+
+// typmodNumericCodec is the codec for a numeric(precision, scale) type. It rounds values to the scale before they are
+// encoded.
+type typmodNumericCodec struct {
+	NumericCodec
+	typmod NumericTypmod
+}
+
+func (c *typmodNumericCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	if _, ok := value.(pgtype.NumericValuer); ok {
+		next := c.NumericCodec.PlanEncode(m, oid, format, pgtype.Numeric{})
+		if next == nil {
+			return nil
+		}
+		return &encodePlanTypmodNumeric{next: next, typmod: c.typmod}
+	}
+
+	return c.NumericCodec.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanTypmodNumeric struct {
+	next   pgtype.EncodePlan
+	typmod NumericTypmod
+}
+
+func (plan *encodePlanTypmodNumeric) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	n, err := value.(pgtype.NumericValuer).NumericValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if !n.NaN && n.InfinityModifier == pgtype.Finite {
+		d, err := plan.typmod.Round(decimal.NewFromBigInt(n.Int, n.Exp))
+		if err != nil {
+			return nil, err
+		}
+		n = pgtype.Numeric{Int: d.Coefficient(), Exp: d.Exponent(), Valid: true}
+	}
+
+	return plan.next.Encode(n, buf)
+}
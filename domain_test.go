@@ -0,0 +1,123 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterDomain(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, `create domain pgxdecimal_amount as numeric(6,2); create domain pgxdecimal_any as numeric`)
+		require.NoError(t, err)
+
+		require.NoError(t, pgxdecimal.RegisterDomain(ctx, conn, "pgxdecimal_amount"))
+		require.NoError(t, pgxdecimal.RegisterDomain(ctx, conn, "pgxdecimal_any"))
+
+		rows, err := conn.Query(ctx, `select 12.5::pgxdecimal_amount, '{1.25,NULL}'::pgxdecimal_amount[], 1.5::pgxdecimal_any`)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		require.Equal(t, decimal.RequireFromString("12.50"), values[0])
+		require.Equal(t, []interface{}{decimal.RequireFromString("1.25"), nil}, values[1])
+		require.Equal(t, decimal.RequireFromString("1.5"), values[2])
+
+		// Values are rounded to the scale of the domain before they are encoded.
+		var s string
+		err = conn.QueryRow(ctx, `select $1::pgxdecimal_amount::text`, decimal.RequireFromString("1.005")).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "1.01", s)
+
+		var ds []decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select $1::pgxdecimal_amount[]`, []decimal.Decimal{decimal.RequireFromString("0.125")}).Scan(&ds)
+		require.NoError(t, err)
+		require.Equal(t, []decimal.NullDecimal{{Decimal: decimal.RequireFromString("0.13"), Valid: true}}, ds)
+
+		_, err = conn.Exec(ctx, `select $1::pgxdecimal_amount`, decimal.RequireFromString("9999.995"))
+		var overflowErr *pgxdecimal.NumericFieldOverflowError
+		require.True(t, errors.As(err, &overflowErr), err)
+		require.Equal(t, pgxdecimal.NumericTypmod{Precision: 6, Scale: 2}, overflowErr.Typmod)
+
+		err = pgxdecimal.RegisterDomain(ctx, conn, "text")
+		require.EqualError(t, err, "text is not a domain over numeric")
+	})
+}
+
+func TestNumericDomains(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, `create schema pgxdecimal_domains;
+create domain pgxdecimal_domains.amount as numeric(6,2);
+create domain pgxdecimal_domains.positive_amount as pgxdecimal_domains.amount check (value > 0);
+create domain pgxdecimal_domains.label as text`)
+		require.NoError(t, err)
+
+		domains := pgxdecimal.NewNumericDomains("pgxdecimal_domains")
+
+		discovered, err := domains.Domains(ctx, conn)
+		require.NoError(t, err)
+		require.Len(t, discovered, 2)
+		require.Equal(t, "pgxdecimal_domains.amount", discovered[0].Name)
+		require.Equal(t, "pgxdecimal_domains.amount[]", discovered[0].ArrayName)
+		require.Equal(t, "pgxdecimal_domains.positive_amount", discovered[1].Name)
+		require.True(t, discovered[1].Constrained)
+		require.Equal(t, pgxdecimal.NumericTypmod{Precision: 6, Scale: 2}, discovered[1].Typmod)
+
+		// The result is a copy of the cache.
+		discovered[0].Name = "modified"
+		discovered, err = domains.Domains(ctx, conn)
+		require.NoError(t, err)
+		require.Equal(t, "pgxdecimal_domains.amount", discovered[0].Name)
+
+		require.NoError(t, domains.Register(ctx, conn))
+
+		var ds []decimal.Decimal
+		err = conn.QueryRow(ctx, `select $1::pgxdecimal_domains.positive_amount[]`, []decimal.Decimal{decimal.RequireFromString("1.005")}).Scan(&ds)
+		require.NoError(t, err)
+		require.Equal(t, []decimal.Decimal{decimal.RequireFromString("1.01")}, ds)
+
+		// The result is cached until invalidated.
+		_, err = tx.Exec(ctx, `create domain pgxdecimal_domains.rate as numeric`)
+		require.NoError(t, err)
+
+		discovered, err = domains.Domains(ctx, conn)
+		require.NoError(t, err)
+		require.Len(t, discovered, 2)
+
+		domains.Invalidate(conn)
+		discovered, err = domains.Domains(ctx, conn)
+		require.NoError(t, err)
+		require.Len(t, discovered, 3)
+		require.False(t, discovered[2].Constrained)
+
+		// Names depend on the search_path. Older servers do not report a search_path changed with SET so the cache
+		// must be invalidated.
+		_, err = tx.Exec(ctx, `set local search_path to pgxdecimal_domains, public`)
+		require.NoError(t, err)
+		domains.Invalidate(conn)
+		discovered, err = domains.Domains(ctx, conn)
+		require.NoError(t, err)
+		require.Len(t, discovered, 3)
+		require.Equal(t, "amount", discovered[0].Name)
+
+		discovered, err = pgxdecimal.NewNumericDomains("pgxdecimal_no_such_schema").Domains(ctx, conn)
+		require.NoError(t, err)
+		require.Empty(t, discovered)
+	})
+}
@@ -0,0 +1,84 @@
+package decimal
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const loadExtensionTypeSQL = `select t.oid, coalesce(a.typname::text, ''), coalesce(a.oid, 0)
+from pg_type t
+  left join pg_type a on a.oid = t.typarray
+where t.oid = $1::text::regtype::oid`
+
+// ExtensionTypeOptions configures RegisterExtensionType.
+type ExtensionTypeOptions struct {
+	// BinaryNumeric uses the binary format for the type. It must only be set if the type's binary format is the same as
+	// numeric's.
+	BinaryNumeric bool
+}
+
+// RegisterExtensionType registers name, a numeric-like type such as those defined by extensions (e.g. pguint's uint8),
+// and its array type with conn's type map. e.g.
+//
+//	err := pgxdecimal.RegisterExtensionType(ctx, conn, "uint8", pgxdecimal.ExtensionTypeOptions{})
+//
+// Values of the type are decoded as decimal.Decimal and can be scanned into and encoded from decimal.Decimal and the
+// other types supported for numeric. Values are exchanged in the text format unless opts.BinaryNumeric is set. Encoded
+// values are written as plain decimal numbers without an exponent.
+//
+// The type is registered as name and its array type as the array type's name in pg_type (e.g. _uint8). conn's type
+// map must already have been registered with Register or RegisterWithOptions.
+func RegisterExtensionType(ctx context.Context, conn *pgx.Conn, name string, opts ExtensionTypeOptions) error {
+	var oid, arrayOID uint32
+	var arrayName string
+	err := conn.QueryRow(ctx, loadExtensionTypeSQL, name).Scan(&oid, &arrayName, &arrayOID)
+	if err != nil {
+		return err
+	}
+
+	m := conn.TypeMap()
+	dt := &pgtype.Type{Name: name, OID: oid, Codec: &extensionCodec{binary: opts.BinaryNumeric}}
+	m.RegisterType(dt)
+
+	if arrayOID != 0 {
+		m.RegisterType(&pgtype.Type{Name: arrayName, OID: arrayOID, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+	}
+
+	return nil
+}
+
+// extensionCodec is the codec for a numeric-like type that is only known to have the same text format as numeric
+// unless binary is set.
+type extensionCodec struct {
+	NumericCodec
+	binary bool
+}
+
+func (c *extensionCodec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || (c.binary && format == pgtype.BinaryFormatCode)
+}
+
+func (c *extensionCodec) PreferredFormat() int16 {
+	if c.binary {
+		return pgtype.BinaryFormatCode
+	}
+	return pgtype.TextFormatCode
+}
+
+func (c *extensionCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	if !c.FormatSupported(format) {
+		return nil
+	}
+
+	return c.NumericCodec.PlanEncode(m, oid, format, value)
+}
+
+func (c *extensionCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	if !c.FormatSupported(format) {
+		return nil
+	}
+
+	return c.NumericCodec.PlanScan(m, oid, format, target)
+}
@@ -0,0 +1,66 @@
+package decimal_test
+
+import (
+	"context"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterExtensionType(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		skipPostgreSQLVersionLessThan(t, conn, 130000)
+
+		// xid8 is not known to pgx and has the text format of an unsigned 64-bit integer like pguint's uint8.
+		err := pgxdecimal.RegisterExtensionType(ctx, conn, "xid8", pgxdecimal.ExtensionTypeOptions{})
+		require.NoError(t, err)
+
+		max := decimal.RequireFromString("18446744073709551615")
+
+		rows, err := conn.Query(ctx, `select $1::text::xid8, $2::xid8[]`, "18446744073709551615", []decimal.Decimal{max, decimal.Zero})
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		require.Equal(t, max, values[0])
+		require.Equal(t, []interface{}{max, decimal.Zero}, values[1])
+
+		var d decimal.Decimal
+		var nd decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select $1::xid8, null::xid8`, max).Scan(&d, &nd)
+		require.NoError(t, err)
+		require.Equal(t, max, d)
+		require.False(t, nd.Valid)
+
+		// Encoded values do not use an exponent.
+		err = conn.QueryRow(ctx, `select $1::xid8`, decimal.New(12, 3)).Scan(&d)
+		require.NoError(t, err)
+		require.Equal(t, "12000", d.String())
+	})
+}
+
+func TestRegisterExtensionTypeBinaryNumeric(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		// Parameters of a domain type are described with the domain's OID and a domain has its base type's binary format.
+		_, err = tx.Exec(ctx, `create domain pgxdecimal_extension as numeric`)
+		require.NoError(t, err)
+
+		err = pgxdecimal.RegisterExtensionType(ctx, conn, "pgxdecimal_extension", pgxdecimal.ExtensionTypeOptions{BinaryNumeric: true})
+		require.NoError(t, err)
+
+		var ds []decimal.Decimal
+		err = conn.QueryRow(ctx, `select $1::pgxdecimal_extension[]`, []decimal.Decimal{decimal.RequireFromString("-1.250")}).Scan(&ds)
+		require.NoError(t, err)
+		require.Equal(t, []decimal.Decimal{decimal.RequireFromString("-1.250")}, ds)
+	})
+}
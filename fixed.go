@@ -0,0 +1,206 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// FixedOverflowError is returned when a value is out of range for a Fixed with the given scale.
+type FixedOverflowError struct {
+	Value decimal.Decimal
+	Scale int32
+}
+
+func (e *FixedOverflowError) Error() string {
+	return fmt.Sprintf("%v is out of range for a fixed-point decimal with scale %d", e.Value, e.Scale)
+}
+
+// Fixed is a fixed-point decimal with the value Int / 10^Scale. It is an alternative to decimal.Decimal that does not
+// allocate for most operations. It implements the same numeric, float and integer scanner and valuer interfaces as
+// Decimal.
+//
+// Values scanned into a Fixed are rounded half away from zero to its Scale like PostgreSQL rounds values assigned to a
+// numeric(p,s) column, so the Scale of the target must be set before scanning. A Fixed that nobody set the Scale of has
+// a Scale of 0 and silently loses every fractional digit. For the same reason Fixed should not be used as the element
+// type of a slice scanned from an array, and Register does not register []Fixed as numeric[]. Values that are out of
+// range after rounding fail with a *FixedOverflowError.
+//
+//	amount := pgxdecimal.Fixed{Scale: 2}
+//	err := conn.QueryRow(ctx, "select amount from payments").Scan(&amount)
+type Fixed struct {
+	Int   int64
+	Scale int32
+}
+
+// pow10 holds the powers of 10 that fit in an int64.
+var pow10 = [...]int64{
+	1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// NewFixed returns d rounded half away from zero to scale.
+func NewFixed(d decimal.Decimal, scale int32) (Fixed, error) {
+	n := d.Round(scale).Shift(scale)
+	if !n.BigInt().IsInt64() {
+		return Fixed{}, &FixedOverflowError{Value: d, Scale: scale}
+	}
+
+	return Fixed{Int: n.IntPart(), Scale: scale}, nil
+}
+
+// Decimal returns f as a decimal.Decimal.
+func (f Fixed) Decimal() decimal.Decimal {
+	return decimal.New(f.Int, -f.Scale)
+}
+
+func (f Fixed) String() string {
+	return f.Decimal().StringFixed(f.Scale)
+}
+
+// rescale returns coefficient * 10^exp rounded half away from zero to f.Scale and expressed as an integer coefficient
+// of f.Scale.
+func (f Fixed) rescale(coefficient int64, exp int32) (int64, bool) {
+	shift := int64(exp) + int64(f.Scale)
+
+	if shift >= 0 {
+		if coefficient == 0 {
+			return 0, true
+		}
+		if shift >= int64(len(pow10)) {
+			return 0, false
+		}
+		p := pow10[shift]
+		if coefficient > math.MaxInt64/p || coefficient < math.MinInt64/p {
+			return 0, false
+		}
+		return coefficient * p, true
+	}
+
+	if -shift >= int64(len(pow10)) {
+		// |coefficient| < 10^19 so the result rounds to zero, except that dividing by exactly 10^19 rounds
+		// |coefficient| >= 5*10^18 away from zero.
+		if -shift == int64(len(pow10)) {
+			if coefficient >= 5e18 {
+				return 1, true
+			} else if coefficient <= -5e18 {
+				return -1, true
+			}
+		}
+		return 0, true
+	}
+	p := pow10[-shift]
+	q, r := coefficient/p, coefficient%p
+	if r >= (p+1)/2 {
+		q++
+	} else if r <= -(p+1)/2 {
+		q--
+	}
+	return q, true
+}
+
+// setDecimal sets f to d rounded to f.Scale.
+func (f *Fixed) setDecimal(d decimal.Decimal) error {
+	fixed, err := NewFixed(d, f.Scale)
+	if err != nil {
+		return err
+	}
+
+	f.Int = fixed.Int
+	return nil
+}
+
+func (f *Fixed) ScanNumeric(v pgtype.Numeric) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into *decimal.Fixed")
+	}
+
+	if v.NaN {
+		return fmt.Errorf("cannot scan NaN into *decimal.Fixed")
+	}
+
+	if v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("cannot scan %v into *decimal.Fixed", v.InfinityModifier)
+	}
+
+	if v.Int.IsInt64() {
+		n, ok := f.rescale(v.Int.Int64(), v.Exp)
+		if !ok {
+			return &FixedOverflowError{Value: decimal.NewFromBigInt(v.Int, v.Exp), Scale: f.Scale}
+		}
+		f.Int = n
+		return nil
+	}
+
+	return f.setDecimal(decimal.NewFromBigInt(v.Int, v.Exp))
+}
+
+func (f Fixed) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(f.Int), Exp: -f.Scale, Valid: true}, nil
+}
+
+func (f *Fixed) ScanFloat64(v pgtype.Float8) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into *decimal.Fixed")
+	}
+
+	if math.IsNaN(v.Float64) {
+		return fmt.Errorf("cannot scan NaN into *decimal.Fixed")
+	}
+
+	if math.IsInf(v.Float64, 0) {
+		return fmt.Errorf("cannot scan %v into *decimal.Fixed", v.Float64)
+	}
+
+	return f.setDecimal(decimal.NewFromFloat(v.Float64))
+}
+
+func (f Fixed) Float64Value() (pgtype.Float8, error) {
+	// Both operands are exact so the quotient is correctly rounded.
+	if f.Int > -1<<53 && f.Int < 1<<53 && f.Scale >= 0 && f.Scale <= 22 {
+		return pgtype.Float8{Float64: float64(f.Int) / math.Pow10(int(f.Scale)), Valid: true}, nil
+	}
+
+	return pgtype.Float8{Float64: f.Decimal().InexactFloat64(), Valid: true}, nil
+}
+
+func (f *Fixed) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into *decimal.Fixed")
+	}
+
+	n, ok := f.rescale(v.Int64, 0)
+	if !ok {
+		return &FixedOverflowError{Value: decimal.NewFromInt(v.Int64), Scale: f.Scale}
+	}
+	f.Int = n
+
+	return nil
+}
+
+func (f Fixed) Int64Value() (pgtype.Int8, error) {
+	if f.Scale <= 0 {
+		// Rescale to an integer with scale 0.
+		n, ok := Fixed{}.rescale(f.Int, -f.Scale)
+		if !ok {
+			return pgtype.Int8{}, &IntegerOverflowError{Value: f.Decimal(), TypeName: "int8"}
+		}
+		return pgtype.Int8{Int64: n, Valid: true}, nil
+	}
+
+	if int(f.Scale) >= len(pow10) {
+		if f.Int != 0 {
+			return pgtype.Int8{}, fmt.Errorf("cannot convert %v to int64", f)
+		}
+		return pgtype.Int8{Valid: true}, nil
+	}
+
+	p := pow10[f.Scale]
+	if f.Int%p != 0 {
+		return pgtype.Int8{}, fmt.Errorf("cannot convert %v to int64", f)
+	}
+
+	return pgtype.Int8{Int64: f.Int / p, Valid: true}, nil
+}
@@ -0,0 +1,170 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func isExpectedEqFixed(a pgxdecimal.Fixed) func(interface{}) bool {
+	return func(v interface{}) bool {
+		return a == v.(pgxdecimal.Fixed)
+	}
+}
+
+func TestValueRoundTripFixed(t *testing.T) {
+	for _, pgTypeName := range []string{"numeric", "float8", "int8"} {
+		pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, pgTypeName, []pgxtest.ValueRoundTripTest{
+			{
+				Param:  pgxdecimal.Fixed{Int: 1200, Scale: 2},
+				Result: &pgxdecimal.Fixed{Scale: 2},
+				Test:   isExpectedEqFixed(pgxdecimal.Fixed{Int: 1200, Scale: 2}),
+			},
+			{
+				Param:  pgxdecimal.Fixed{Int: -5, Scale: 0},
+				Result: &pgxdecimal.Fixed{Scale: 4},
+				Test:   isExpectedEqFixed(pgxdecimal.Fixed{Int: -50000, Scale: 4}),
+			},
+			{
+				Param:  pgxdecimal.Fixed{Int: 12, Scale: -3},
+				Result: &pgxdecimal.Fixed{Scale: -3},
+				Test:   isExpectedEqFixed(pgxdecimal.Fixed{Int: 12, Scale: -3}),
+			},
+		})
+	}
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "numeric", []pgxtest.ValueRoundTripTest{
+		{
+			Param:  pgxdecimal.Fixed{Int: math.MaxInt64, Scale: 18},
+			Result: &pgxdecimal.Fixed{Scale: 18},
+			Test:   isExpectedEqFixed(pgxdecimal.Fixed{Int: math.MaxInt64, Scale: 18}),
+		},
+		{
+			Param:  pgxdecimal.Fixed{Int: 12345, Scale: 3},
+			Result: &pgxdecimal.Fixed{Scale: 2},
+			Test:   isExpectedEqFixed(pgxdecimal.Fixed{Int: 1235, Scale: 2}),
+		},
+		{
+			Param:  decimal.RequireFromString("-0.005"),
+			Result: &pgxdecimal.Fixed{Scale: 2},
+			Test:   isExpectedEqFixed(pgxdecimal.Fixed{Int: -1, Scale: 2}),
+		},
+	})
+}
+
+func TestFixedScanNumeric(t *testing.T) {
+	for i, tt := range []struct {
+		value    pgtype.Numeric
+		scale    int32
+		expected int64
+		overflow bool
+	}{
+		{value: pgtype.Numeric{Int: big.NewInt(1234), Exp: -2, Valid: true}, scale: 2, expected: 1234},
+		{value: pgtype.Numeric{Int: big.NewInt(1234), Exp: -2, Valid: true}, scale: 4, expected: 123400},
+		{value: pgtype.Numeric{Int: big.NewInt(1235), Exp: -3, Valid: true}, scale: 2, expected: 124},
+		{value: pgtype.Numeric{Int: big.NewInt(-1235), Exp: -3, Valid: true}, scale: 2, expected: -124},
+		{value: pgtype.Numeric{Int: big.NewInt(-1234), Exp: -3, Valid: true}, scale: 2, expected: -123},
+		{value: pgtype.Numeric{Int: big.NewInt(5), Exp: -30, Valid: true}, scale: 2, expected: 0},
+		{value: pgtype.Numeric{Int: big.NewInt(0), Exp: 100, Valid: true}, scale: 2, expected: 0},
+		{value: pgtype.Numeric{Int: big.NewInt(6e18), Exp: -19, Valid: true}, scale: 0, expected: 1},
+		{value: pgtype.Numeric{Int: big.NewInt(5e18), Exp: -19, Valid: true}, scale: 0, expected: 1},
+		{value: pgtype.Numeric{Int: big.NewInt(-5e18), Exp: -19, Valid: true}, scale: 0, expected: -1},
+		{value: pgtype.Numeric{Int: big.NewInt(4999999999999999999), Exp: -19, Valid: true}, scale: 0, expected: 0},
+		{value: pgtype.Numeric{Int: big.NewInt(math.MinInt64), Exp: -19, Valid: true}, scale: 0, expected: -1},
+		{value: pgtype.Numeric{Int: big.NewInt(6e18), Exp: -20, Valid: true}, scale: 0, expected: 0},
+		{value: pgtype.Numeric{Int: big.NewInt(12), Exp: 3, Valid: true}, scale: -3, expected: 12},
+		{value: pgtype.Numeric{Int: big.NewInt(92233720368547759), Exp: 0, Valid: true}, scale: 2, overflow: true},
+		{value: pgtype.Numeric{Int: big.NewInt(1), Exp: 19, Valid: true}, scale: 0, overflow: true},
+		{value: pgtype.Numeric{Int: new(big.Int).Lsh(big.NewInt(1), 70), Exp: -15, Valid: true}, scale: 2, expected: 118059162},
+		{value: pgtype.Numeric{Int: new(big.Int).Lsh(big.NewInt(1), 70), Exp: 0, Valid: true}, scale: 0, overflow: true},
+	} {
+		f := pgxdecimal.Fixed{Scale: tt.scale}
+		err := f.ScanNumeric(tt.value)
+		if tt.overflow {
+			var overflowErr *pgxdecimal.FixedOverflowError
+			require.Truef(t, errors.As(err, &overflowErr), "%d: %v", i, err)
+			continue
+		}
+
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, pgxdecimal.Fixed{Int: tt.expected, Scale: tt.scale}, f, "%d", i)
+	}
+
+	var f pgxdecimal.Fixed
+	require.Error(t, f.ScanNumeric(pgtype.Numeric{}))
+	require.Error(t, f.ScanNumeric(pgtype.Numeric{NaN: true, Valid: true}))
+	require.Error(t, f.ScanNumeric(pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}))
+}
+
+func TestFixedConversions(t *testing.T) {
+	f, err := pgxdecimal.NewFixed(decimal.RequireFromString("12.345"), 2)
+	require.NoError(t, err)
+	require.Equal(t, pgxdecimal.Fixed{Int: 1235, Scale: 2}, f)
+	require.True(t, f.Decimal().Equal(decimal.RequireFromString("12.35")))
+	require.Equal(t, "12.35", f.String())
+
+	_, err = pgxdecimal.NewFixed(decimal.RequireFromString("92233720368547758.08"), 2)
+	var overflowErr *pgxdecimal.FixedOverflowError
+	require.True(t, errors.As(err, &overflowErr), err)
+
+	f8, err := pgxdecimal.Fixed{Int: 1, Scale: 1}.Float64Value()
+	require.NoError(t, err)
+	require.Equal(t, 0.1, f8.Float64)
+
+	f = pgxdecimal.Fixed{Scale: 2}
+	require.NoError(t, f.ScanFloat64(pgtype.Float8{Float64: 1.005, Valid: true}))
+	require.Equal(t, pgxdecimal.Fixed{Int: 101, Scale: 2}, f)
+
+	n, err := pgxdecimal.Fixed{Int: -1200, Scale: 2}.Int64Value()
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Int8{Int64: -12, Valid: true}, n)
+
+	n, err = pgxdecimal.Fixed{Int: 12, Scale: -3}.Int64Value()
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Int8{Int64: 12000, Valid: true}, n)
+
+	_, err = pgxdecimal.Fixed{Int: 1250, Scale: 2}.Int64Value()
+	require.Error(t, err)
+
+	_, err = pgxdecimal.Fixed{Int: math.MaxInt64, Scale: -1}.Int64Value()
+	var intOverflowErr *pgxdecimal.IntegerOverflowError
+	require.True(t, errors.As(err, &intOverflowErr), err)
+
+	f = pgxdecimal.Fixed{Scale: 2}
+	require.NoError(t, f.ScanInt64(pgtype.Int8{Int64: -7, Valid: true}))
+	require.Equal(t, pgxdecimal.Fixed{Int: -700, Scale: 2}, f)
+	err = f.ScanInt64(pgtype.Int8{Int64: math.MaxInt64, Valid: true})
+	require.True(t, errors.As(err, &overflowErr), err)
+}
+
+func TestFixedDoesNotAllocate(t *testing.T) {
+	f := pgxdecimal.Fixed{Scale: 2}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = f.ScanInt64(pgtype.Int8{Int64: 1234, Valid: true})
+		_, _ = f.Int64Value()
+		_, _ = f.Float64Value()
+	})
+	require.Zero(t, allocs)
+
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+	plan := m.PlanScan(pgtype.Int8OID, pgtype.BinaryFormatCode, &f)
+	src := []byte{0, 0, 0, 0, 0, 0, 0x04, 0xd2}
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = plan.Scan(src, &f)
+	})
+	require.Zero(t, allocs)
+	require.Equal(t, pgxdecimal.Fixed{Int: 123400, Scale: 2}, f)
+
+	err := m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, []byte("-1234.5678"), &f)
+	require.NoError(t, err)
+	require.Equal(t, pgxdecimal.Fixed{Int: -123457, Scale: 2}, f)
+}
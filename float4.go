@@ -0,0 +1,193 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// float4Scanner is implemented by scan targets that convert a float4 to a decimal without first widening it to a
+// float64. e.g. float4 0.1 is scanned as 0.1 instead of 0.10000000149011612.
+type float4Scanner interface {
+	ScanFloat4(v pgtype.Float4) error
+}
+
+// float4Valuer is implemented by values that are rounded directly to a float4 instead of through a float64.
+type float4Valuer interface {
+	Float4Value() (pgtype.Float4, error)
+}
+
+// FloatRangeError is returned when a decimal is too large or too small in magnitude to be represented by a float type.
+type FloatRangeError struct {
+	Value decimal.Decimal
+
+	// TypeName is the name of the PostgreSQL float type.
+	TypeName string
+}
+
+func (e *FloatRangeError) Error() string {
+	return fmt.Sprintf("%v is out of range for %s", e.Value, e.TypeName)
+}
+
+// float4Value returns d rounded to the nearest float32.
+func float4Value(d decimal.Decimal) (pgtype.Float4, error) {
+	f, err := strconv.ParseFloat(d.String(), 32)
+	if err != nil || (f == 0 && !d.IsZero()) {
+		return pgtype.Float4{}, &FloatRangeError{Value: d, TypeName: "float4"}
+	}
+
+	return pgtype.Float4{Float32: float32(f), Valid: true}, nil
+}
+
+func (d *Decimal) ScanFloat4(v pgtype.Float4) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into *decimal.Decimal")
+	}
+
+	if math.IsNaN(float64(v.Float32)) {
+		return fmt.Errorf("cannot scan NaN into *decimal.Decimal")
+	}
+
+	if math.IsInf(float64(v.Float32), 0) {
+		return fmt.Errorf("cannot scan %v into *decimal.Decimal", v.Float32)
+	}
+
+	*d = Decimal(decimal.NewFromFloat32(v.Float32))
+
+	return nil
+}
+
+func (d Decimal) Float4Value() (pgtype.Float4, error) {
+	return float4Value(decimal.Decimal(d))
+}
+
+func (d *NullDecimal) ScanFloat4(v pgtype.Float4) error {
+	if !v.Valid {
+		*d = NullDecimal{}
+		return nil
+	}
+
+	if math.IsNaN(float64(v.Float32)) {
+		return fmt.Errorf("cannot scan NaN into *decimal.NullDecimal")
+	}
+
+	if math.IsInf(float64(v.Float32), 0) {
+		return fmt.Errorf("cannot scan %v into *decimal.NullDecimal", v.Float32)
+	}
+
+	*d = NullDecimal(decimal.NullDecimal{Decimal: decimal.NewFromFloat32(v.Float32), Valid: true})
+
+	return nil
+}
+
+func (d NullDecimal) Float4Value() (pgtype.Float4, error) {
+	if !d.Valid {
+		return pgtype.Float4{}, nil
+	}
+
+	return float4Value(d.Decimal)
+}
+
+// float4Codec wraps the float4 codec so decimals are converted directly to and from float32. ScanAdapters are scanned
+// subject to opts.
+type float4Codec struct {
+	pgtype.Codec
+	opts *Options
+}
+
+func registerFloat4Codec(m *pgtype.Map, opts *Options) {
+	dt, ok := m.TypeForOID(pgtype.Float4OID)
+	if !ok {
+		return
+	}
+
+	codec := dt.Codec
+	if c, ok := codec.(*float4Codec); ok {
+		codec = c.Codec
+	}
+
+	m.RegisterType(&pgtype.Type{Name: dt.Name, OID: dt.OID, Codec: &float4Codec{Codec: codec, opts: opts}})
+}
+
+func (c *float4Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	if _, ok := value.(float4Valuer); ok {
+		next := c.Codec.PlanEncode(m, oid, format, float32(0))
+		if next == nil {
+			return nil
+		}
+		return &encodePlanFloat4Valuer{next: next}
+	}
+
+	return c.Codec.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanFloat4Valuer struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *encodePlanFloat4Valuer) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	f, err := value.(float4Valuer).Float4Value()
+	if err != nil {
+		return nil, err
+	}
+
+	if !f.Valid {
+		return nil, nil
+	}
+
+	return plan.next.Encode(f.Float32, buf)
+}
+
+func (c *float4Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	if _, ok := target.(*ScanAdapter); ok {
+		return planScanAdapterScan(c, m, oid, format, c.opts)
+	}
+
+	if _, ok := target.(float4Scanner); ok {
+		switch format {
+		case pgtype.BinaryFormatCode:
+			return scanPlanBinaryFloat4ToFloat4Scanner{}
+		case pgtype.TextFormatCode:
+			return scanPlanTextAnyToFloat4Scanner{}
+		}
+	}
+
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+type scanPlanBinaryFloat4ToFloat4Scanner struct{}
+
+func (scanPlanBinaryFloat4ToFloat4Scanner) Scan(src []byte, dst interface{}) error {
+	s := dst.(float4Scanner)
+
+	if src == nil {
+		return s.ScanFloat4(pgtype.Float4{})
+	}
+
+	if len(src) != 4 {
+		return fmt.Errorf("invalid length for float4: %v", len(src))
+	}
+
+	return s.ScanFloat4(pgtype.Float4{Float32: math.Float32frombits(binary.BigEndian.Uint32(src)), Valid: true})
+}
+
+type scanPlanTextAnyToFloat4Scanner struct{}
+
+func (scanPlanTextAnyToFloat4Scanner) Scan(src []byte, dst interface{}) error {
+	s := dst.(float4Scanner)
+
+	if src == nil {
+		return s.ScanFloat4(pgtype.Float4{})
+	}
+
+	f, err := strconv.ParseFloat(string(src), 32)
+	if err != nil {
+		return err
+	}
+
+	return s.ScanFloat4(pgtype.Float4{Float32: float32(f), Valid: true})
+}
@@ -0,0 +1,154 @@
+package decimal_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueRoundTripFloat4(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "float4", []pgxtest.ValueRoundTripTest{
+		{
+			Param:  decimal.RequireFromString("1"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("1")),
+		},
+		{
+			Param:  decimal.RequireFromString("0.000012345"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("0.000012345")),
+		},
+		{
+			Param:  decimal.RequireFromString("123456.12"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("123456.12")),
+		},
+		{
+			Param:  decimal.RequireFromString("-1"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("-1")),
+		},
+		{
+			Param:  decimal.RequireFromString("-0.000012345"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("-0.000012345")),
+		},
+		{
+			Param:  decimal.RequireFromString("-123456.12"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("-123456.12")),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("1"), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("1"), Valid: true}),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("0.000012345"), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("0.000012345"), Valid: true}),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("123456.12"), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("123456.12"), Valid: true}),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("-1"), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("-1"), Valid: true}),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("-0.000012345"), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("-0.000012345"), Valid: true}),
+		},
+		{
+			Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("-123456.12"), Valid: true},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("-123456.12"), Valid: true}),
+		},
+		{
+			Param:  decimal.NullDecimal{},
+			Result: new(decimal.NullDecimal),
+			Test:   isExpectedEqNullDecimal(decimal.NullDecimal{}),
+		},
+	})
+}
+
+func TestFloat4Scan(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	for i, tt := range []struct {
+		f        float32
+		expected decimal.Decimal
+	}{
+		{f: 0.1, expected: decimal.RequireFromString("0.1")},
+		{f: 123456.12, expected: decimal.RequireFromString("123456.12")},
+		{f: -3.4e38, expected: decimal.RequireFromString("-3.4e38")},
+	} {
+		src := make([]byte, 4)
+		binary.BigEndian.PutUint32(src, math.Float32bits(tt.f))
+
+		var d decimal.Decimal
+		err := m.Scan(pgtype.Float4OID, pgtype.BinaryFormatCode, src, &d)
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, tt.expected.Equal(d), "%d: expected %v, got %v", i, tt.expected, d)
+
+		var nd decimal.NullDecimal
+		err = m.Scan(pgtype.Float4OID, pgtype.TextFormatCode, []byte(decimal.NewFromFloat32(tt.f).String()), &nd)
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, nd.Valid && tt.expected.Equal(nd.Decimal), "%d: expected %v, got %v", i, tt.expected, nd)
+	}
+}
+
+func TestFloat4Encode(t *testing.T) {
+	for _, opts := range []pgxdecimal.Options{{}, {StrictFloat: true}} {
+		m := pgtype.NewMap()
+		pgxdecimal.RegisterWithOptions(m, opts)
+
+		for i, tt := range []struct {
+			value      interface{}
+			outOfRange bool
+			inexact    bool
+		}{
+			{value: decimal.RequireFromString("0.5")},
+			{value: decimal.RequireFromString("-123456.12")},
+			{value: decimal.RequireFromString("-123456.123456"), inexact: true},
+			{value: decimal.NullDecimal{Decimal: decimal.RequireFromString("16777217"), Valid: true}, inexact: true},
+			{value: decimal.NullDecimal{}},
+			{value: decimal.RequireFromString("1e39"), outOfRange: true},
+			{value: decimal.NullDecimal{Decimal: decimal.RequireFromString("-1e39"), Valid: true}, outOfRange: true},
+			{value: decimal.RequireFromString("1e-50"), outOfRange: true},
+			{value: pgxdecimal.Decimal(decimal.RequireFromString("1e39")), outOfRange: true},
+		} {
+			for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+				plan := m.PlanEncode(pgtype.Float4OID, format, tt.value)
+				require.NotNilf(t, plan, "%d", i)
+
+				_, err := plan.Encode(tt.value, nil)
+				switch {
+				case tt.outOfRange:
+					var rangeErr *pgxdecimal.FloatRangeError
+					require.Truef(t, errors.As(err, &rangeErr), "%d: %v", i, err)
+					require.Equal(t, "float4", rangeErr.TypeName)
+				case tt.inexact && opts.StrictFloat:
+					var inexactErr *pgxdecimal.InexactFloatError
+					require.Truef(t, errors.As(err, &inexactErr), "%d: %v", i, err)
+					require.Equal(t, 32, inexactErr.BitSize)
+				default:
+					require.NoErrorf(t, err, "%d", i)
+				}
+			}
+		}
+	}
+}
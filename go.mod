@@ -1,6 +1,6 @@
 module github.com/jackc/pgx-shopspring-decimal
 
-go 1.17
+go 1.22
 
 require (
 	github.com/jackc/pgx/v5 v5.0.0-alpha.1.0.20220402215505-8cf6721d6672
@@ -0,0 +1,187 @@
+package decimal
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// HstoreNullPolicy determines how NULL hstore values are scanned into a map[string]decimal.Decimal. NULL values are
+// always scanned into a map[string]decimal.NullDecimal as invalid decimals.
+type HstoreNullPolicy int8
+
+const (
+	// HstoreNullError fails the scan.
+	HstoreNullError HstoreNullPolicy = iota
+
+	// HstoreNullZero scans NULL as zero.
+	HstoreNullZero
+
+	// HstoreNullOmit leaves keys with NULL values out of the map.
+	HstoreNullOmit
+)
+
+// hstoreDecimalMap implements pgtype.HstoreValuer for a map[string]decimal.Decimal.
+type hstoreDecimalMap map[string]decimal.Decimal
+
+func (h hstoreDecimalMap) HstoreValue() (pgtype.Hstore, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	hstore := make(pgtype.Hstore, len(h))
+	for k, d := range h {
+		s := d.String()
+		hstore[k] = &s
+	}
+
+	return hstore, nil
+}
+
+// hstoreNullDecimalMap implements pgtype.HstoreValuer for a map[string]decimal.NullDecimal.
+type hstoreNullDecimalMap map[string]decimal.NullDecimal
+
+func (h hstoreNullDecimalMap) HstoreValue() (pgtype.Hstore, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	hstore := make(pgtype.Hstore, len(h))
+	for k, d := range h {
+		if d.Valid {
+			s := d.Decimal.String()
+			hstore[k] = &s
+		} else {
+			hstore[k] = nil
+		}
+	}
+
+	return hstore, nil
+}
+
+// optionsHstoreDecimalMapScanner implements pgtype.HstoreScanner for a *map[string]decimal.Decimal. Values are parsed
+// according to opts.TextParsing and NULL values are handled according to opts.HstoreNull.
+type optionsHstoreDecimalMapScanner struct {
+	m    *map[string]decimal.Decimal
+	opts *Options
+}
+
+func (h optionsHstoreDecimalMapScanner) ScanHstore(v pgtype.Hstore) error {
+	if v == nil {
+		*h.m = nil
+		return nil
+	}
+
+	m := make(map[string]decimal.Decimal, len(v))
+	for k, s := range v {
+		if s == nil {
+			switch h.opts.HstoreNull {
+			case HstoreNullZero:
+				m[k] = decimal.Zero
+			case HstoreNullOmit:
+			default:
+				return fmt.Errorf("hstore key %q: cannot scan NULL into *decimal.Decimal", k)
+			}
+			continue
+		}
+
+		d, err := h.opts.TextParsing.scanText(pgtype.Text{String: *s, Valid: true}, "*decimal.Decimal")
+		if err != nil {
+			return fmt.Errorf("hstore key %q: %w", k, err)
+		}
+		m[k] = d
+	}
+
+	*h.m = m
+	return nil
+}
+
+// optionsHstoreNullDecimalMapScanner implements pgtype.HstoreScanner for a *map[string]decimal.NullDecimal. Values are
+// parsed according to opts.TextParsing.
+type optionsHstoreNullDecimalMapScanner struct {
+	m    *map[string]decimal.NullDecimal
+	opts *Options
+}
+
+func (h optionsHstoreNullDecimalMapScanner) ScanHstore(v pgtype.Hstore) error {
+	if v == nil {
+		*h.m = nil
+		return nil
+	}
+
+	m := make(map[string]decimal.NullDecimal, len(v))
+	for k, s := range v {
+		if s == nil {
+			m[k] = decimal.NullDecimal{}
+			continue
+		}
+
+		d, err := h.opts.TextParsing.scanText(pgtype.Text{String: *s, Valid: true}, "*decimal.NullDecimal")
+		if err != nil {
+			return fmt.Errorf("hstore key %q: %w", k, err)
+		}
+		m[k] = decimal.NullDecimal{Decimal: d, Valid: true}
+	}
+
+	*h.m = m
+	return nil
+}
+
+type wrapHstoreDecimalMapEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapHstoreDecimalMapEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapHstoreDecimalMapEncodePlan) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(hstoreDecimalMap(value.(map[string]decimal.Decimal)), buf)
+}
+
+type wrapHstoreNullDecimalMapEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapHstoreNullDecimalMapEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapHstoreNullDecimalMapEncodePlan) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(hstoreNullDecimalMap(value.(map[string]decimal.NullDecimal)), buf)
+}
+
+type wrapOptionsHstoreDecimalMapScanPlan struct {
+	next pgtype.ScanPlan
+	opts *Options
+}
+
+func (plan *wrapOptionsHstoreDecimalMapScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapOptionsHstoreDecimalMapScanPlan) Scan(src []byte, dst interface{}) error {
+	m := dst.(*map[string]decimal.Decimal)
+
+	// pgx scans NULL as an empty hstore.
+	if src == nil {
+		*m = nil
+		return nil
+	}
+
+	return plan.next.Scan(src, optionsHstoreDecimalMapScanner{m: m, opts: plan.opts})
+}
+
+type wrapOptionsHstoreNullDecimalMapScanPlan struct {
+	next pgtype.ScanPlan
+	opts *Options
+}
+
+func (plan *wrapOptionsHstoreNullDecimalMapScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapOptionsHstoreNullDecimalMapScanPlan) Scan(src []byte, dst interface{}) error {
+	m := dst.(*map[string]decimal.NullDecimal)
+
+	// pgx scans NULL as an empty hstore.
+	if src == nil {
+		*m = nil
+		return nil
+	}
+
+	return plan.next.Scan(src, optionsHstoreNullDecimalMapScanner{m: m, opts: plan.opts})
+}
@@ -0,0 +1,137 @@
+package decimal_test
+
+import (
+	"context"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// hstoreOID is a fake OID for hstore. hstore is an extension so its OID differs between databases.
+const hstoreOID = 100100
+
+func newHstoreMap(opts pgxdecimal.Options) *pgtype.Map {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, opts)
+	m.RegisterType(&pgtype.Type{Name: "hstore", OID: hstoreOID, Codec: pgtype.HstoreCodec{}})
+	return m
+}
+
+func TestHstoreEncodeScan(t *testing.T) {
+	m := newHstoreMap(pgxdecimal.Options{})
+
+	// pgx does not quote keys when encoding hstore in the text format but requires them to be quoted when scanning, so
+	// only the binary format can round trip. The text format is scanned below.
+	for _, format := range []int16{pgtype.BinaryFormatCode} {
+		value := map[string]decimal.NullDecimal{
+			"a": {Decimal: decimal.RequireFromString("1.50"), Valid: true},
+			"b": {Decimal: decimal.RequireFromString("-12345678901234567890.123456789"), Valid: true},
+			"c": {},
+		}
+
+		buf, err := m.Encode(hstoreOID, format, value, nil)
+		require.NoErrorf(t, err, "%d", format)
+
+		var scanned map[string]decimal.NullDecimal
+		err = m.Scan(hstoreOID, format, buf, &scanned)
+		require.NoErrorf(t, err, "%d", format)
+		require.Len(t, scanned, 3)
+		require.Equalf(t, "1.5", scanned["a"].Decimal.String(), "%d", format)
+		require.Truef(t, value["b"].Decimal.Equal(scanned["b"].Decimal), "%d", format)
+		require.Falsef(t, scanned["c"].Valid, "%d", format)
+
+		ds := map[string]decimal.Decimal{"x": decimal.RequireFromString("0.001")}
+		buf, err = m.Encode(hstoreOID, format, ds, nil)
+		require.NoErrorf(t, err, "%d", format)
+
+		var scannedDecimals map[string]decimal.Decimal
+		err = m.Scan(hstoreOID, format, buf, &scannedDecimals)
+		require.NoErrorf(t, err, "%d", format)
+		require.Len(t, scannedDecimals, 1)
+		require.Truef(t, ds["x"].Equal(scannedDecimals["x"]), "%d", format)
+
+		buf, err = m.Encode(hstoreOID, format, map[string]decimal.Decimal(nil), nil)
+		require.NoErrorf(t, err, "%d", format)
+		require.Nilf(t, buf, "%d", format)
+
+		err = m.Scan(hstoreOID, format, nil, &scannedDecimals)
+		require.NoErrorf(t, err, "%d", format)
+		require.Nilf(t, scannedDecimals, "%d", format)
+	}
+
+	var scanned map[string]decimal.NullDecimal
+	err := m.Scan(hstoreOID, pgtype.TextFormatCode, []byte(`"a"=>"1.50", "b"=>NULL`), &scanned)
+	require.NoError(t, err)
+	require.Len(t, scanned, 2)
+	require.Equal(t, "1.5", scanned["a"].Decimal.String())
+	require.False(t, scanned["b"].Valid)
+}
+
+func TestHstoreScanNullPolicy(t *testing.T) {
+	src := []byte(`"a"=>"1.5", "b"=>NULL`)
+
+	var ds map[string]decimal.Decimal
+	err := newHstoreMap(pgxdecimal.Options{}).Scan(hstoreOID, pgtype.TextFormatCode, src, &ds)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `hstore key "b"`)
+
+	err = newHstoreMap(pgxdecimal.Options{HstoreNull: pgxdecimal.HstoreNullZero}).Scan(hstoreOID, pgtype.TextFormatCode, src, &ds)
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+	require.True(t, ds["b"].IsZero())
+
+	err = newHstoreMap(pgxdecimal.Options{HstoreNull: pgxdecimal.HstoreNullOmit}).Scan(hstoreOID, pgtype.TextFormatCode, src, &ds)
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	require.Equal(t, "1.5", ds["a"].String())
+}
+
+func TestHstoreScanTextParsing(t *testing.T) {
+	src := []byte(`"a"=>" 1.5 ", "b"=>"1e3"`)
+
+	var nds map[string]decimal.NullDecimal
+	err := newHstoreMap(pgxdecimal.Options{}).Scan(hstoreOID, pgtype.TextFormatCode, src, &nds)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `hstore key "a"`)
+
+	err = newHstoreMap(pgxdecimal.Options{TextParsing: pgxdecimal.TextParsing{AllowSpace: true}}).Scan(hstoreOID, pgtype.TextFormatCode, src, &nds)
+	require.NoError(t, err)
+	require.Equal(t, "1.5", nds["a"].Decimal.String())
+	require.Equal(t, "1000", nds["b"].Decimal.String())
+
+	err = newHstoreMap(pgxdecimal.Options{TextParsing: pgxdecimal.TextParsing{AllowSpace: true, RejectExponent: true}}).Scan(hstoreOID, pgtype.TextFormatCode, src, &nds)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `hstore key "b"`)
+}
+
+func TestHstore(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var oid uint32
+		err := conn.QueryRow(ctx, `select oid from pg_type where typname = 'hstore'`).Scan(&oid)
+		if err == pgx.ErrNoRows {
+			t.Skip("hstore extension is not installed")
+		}
+		require.NoError(t, err)
+
+		conn.TypeMap().RegisterType(&pgtype.Type{Name: "hstore", OID: oid, Codec: pgtype.HstoreCodec{}})
+
+		var nds map[string]decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select $1::hstore`, map[string]decimal.NullDecimal{
+			"a": {Decimal: decimal.RequireFromString("0.10"), Valid: true},
+			"b": {},
+		}).Scan(&nds)
+		require.NoError(t, err)
+		require.Len(t, nds, 2)
+		require.Equal(t, "0.1", nds["a"].Decimal.String())
+		require.False(t, nds["b"].Valid)
+
+		var ds map[string]decimal.Decimal
+		err = conn.QueryRow(ctx, `select 'x=>123456789012345678901234567890.123456789'::hstore`).Scan(&ds)
+		require.NoError(t, err)
+		require.Equal(t, "123456789012345678901234567890.123456789", ds["x"].String())
+	})
+}
@@ -0,0 +1,101 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// IntegerOverflowError is returned when a decimal is out of range for the integer type it is encoded as.
+type IntegerOverflowError struct {
+	Value decimal.Decimal
+
+	// TypeName is the name of the PostgreSQL integer type: int2, int4, int8 or money.
+	TypeName string
+}
+
+func (e *IntegerOverflowError) Error() string {
+	return fmt.Sprintf("%v is out of range for %s", e.Value, e.TypeName)
+}
+
+// int64Value returns d as a pgtype.Int8. d must be an integer.
+func int64Value(d decimal.Decimal) (pgtype.Int8, error) {
+	if !d.IsInteger() {
+		return pgtype.Int8{}, fmt.Errorf("cannot convert %v to int64", d)
+	}
+
+	bi := d.BigInt()
+	if !bi.IsInt64() {
+		return pgtype.Int8{}, &IntegerOverflowError{Value: d, TypeName: "int8"}
+	}
+
+	return pgtype.Int8{Int64: bi.Int64(), Valid: true}, nil
+}
+
+// intRangeCodec wraps the codec of a PostgreSQL integer type narrower than int8 so decimals that are out of range fail
+// with an *IntegerOverflowError like int8 does.
+type intRangeCodec struct {
+	pgtype.Codec
+	typeName string
+	min      int64
+	max      int64
+}
+
+func registerIntRangeCodecs(m *pgtype.Map) {
+	for _, t := range []struct {
+		name string
+		oid  uint32
+		min  int64
+		max  int64
+	}{
+		{name: "int2", oid: pgtype.Int2OID, min: math.MinInt16, max: math.MaxInt16},
+		{name: "int4", oid: pgtype.Int4OID, min: math.MinInt32, max: math.MaxInt32},
+	} {
+		dt, ok := m.TypeForOID(t.oid)
+		if !ok {
+			continue
+		}
+		if _, ok := dt.Codec.(*intRangeCodec); ok {
+			continue
+		}
+
+		m.RegisterType(&pgtype.Type{
+			Name:  dt.Name,
+			OID:   dt.OID,
+			Codec: &intRangeCodec{Codec: dt.Codec, typeName: t.name, min: t.min, max: t.max},
+		})
+	}
+}
+
+func (c *intRangeCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	switch value.(type) {
+	case Decimal, NullDecimal, Fixed, optionsDecimal, optionsNullDecimal, *ScaledInt:
+		next := c.Codec.PlanEncode(m, oid, format, pgtype.Int8{})
+		if next == nil {
+			return nil
+		}
+		return &encodePlanIntRange{next: next, codec: c}
+	}
+
+	return c.Codec.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanIntRange struct {
+	next  pgtype.EncodePlan
+	codec *intRangeCodec
+}
+
+func (plan *encodePlanIntRange) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	n, err := value.(pgtype.Int64Valuer).Int64Value()
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Valid && (n.Int64 < plan.codec.min || n.Int64 > plan.codec.max) {
+		return nil, &IntegerOverflowError{Value: decimal.NewFromInt(n.Int64), TypeName: plan.codec.typeName}
+	}
+
+	return plan.next.Encode(n, buf)
+}
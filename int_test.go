@@ -0,0 +1,121 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegerOverflowError(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	for i, tt := range []struct {
+		oid      uint32
+		value    interface{}
+		typeName string
+	}{
+		{oid: pgtype.Int2OID, value: decimal.NewFromInt(32768), typeName: "int2"},
+		{oid: pgtype.Int2OID, value: decimal.NullDecimal{Decimal: decimal.NewFromInt(-32769), Valid: true}, typeName: "int2"},
+		{oid: pgtype.Int4OID, value: decimal.NewFromInt(2147483648), typeName: "int4"},
+		{oid: pgtype.Int4OID, value: pgxdecimal.Decimal(decimal.NewFromInt(-2147483649)), typeName: "int4"},
+		{oid: pgtype.Int2OID, value: pgxdecimal.NullDecimal{Decimal: decimal.NewFromInt(40000), Valid: true}, typeName: "int2"},
+		{oid: pgtype.Int8OID, value: decimal.RequireFromString("9223372036854775808"), typeName: "int8"},
+		{oid: pgtype.Int8OID, value: decimal.NullDecimal{Decimal: decimal.RequireFromString("-9223372036854775809"), Valid: true}, typeName: "int8"},
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			plan := m.PlanEncode(tt.oid, format, tt.value)
+			require.NotNilf(t, plan, "%d", i)
+
+			_, err := plan.Encode(tt.value, nil)
+			var overflowErr *pgxdecimal.IntegerOverflowError
+			require.Truef(t, errors.As(err, &overflowErr), "%d: %v", i, err)
+			require.Equalf(t, tt.typeName, overflowErr.TypeName, "%d", i)
+		}
+	}
+
+	// In range values and other types are unaffected.
+	for i, tt := range []struct {
+		oid   uint32
+		value interface{}
+	}{
+		{oid: pgtype.Int2OID, value: decimal.NewFromInt(32767)},
+		{oid: pgtype.Int2OID, value: decimal.NullDecimal{}},
+		{oid: pgtype.Int4OID, value: decimal.NewFromInt(-2147483648)},
+		{oid: pgtype.Int4OID, value: int32(7)},
+		{oid: pgtype.Int4OID, value: int64(7)},
+	} {
+		_, err := m.Encode(tt.oid, pgtype.BinaryFormatCode, tt.value, nil)
+		require.NoErrorf(t, err, "%d", i)
+	}
+
+	_, err := m.Encode(pgtype.Int4OID, pgtype.BinaryFormatCode, int64(2147483648), nil)
+	require.Error(t, err)
+}
+
+func TestRoundIntegers(t *testing.T) {
+	for i, tt := range []struct {
+		mode     pgxdecimal.RoundingMode
+		value    interface{}
+		expected int64
+	}{
+		{mode: pgxdecimal.HalfAwayFromZero, value: decimal.RequireFromString("2.5"), expected: 3},
+		{mode: pgxdecimal.HalfAwayFromZero, value: decimal.RequireFromString("-2.5"), expected: -3},
+		{mode: pgxdecimal.HalfEven, value: decimal.RequireFromString("2.5"), expected: 2},
+		{mode: pgxdecimal.TowardZero, value: decimal.NullDecimal{Decimal: decimal.RequireFromString("-2.9"), Valid: true}, expected: -2},
+		{mode: pgxdecimal.Floor, value: decimal.RequireFromString("-2.1"), expected: -3},
+	} {
+		m := pgtype.NewMap()
+		pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{RoundIntegers: true, IntRounding: tt.mode})
+
+		buf, err := m.Encode(pgtype.Int8OID, pgtype.TextFormatCode, tt.value, nil)
+		require.NoErrorf(t, err, "%d", i)
+
+		var n int64
+		err = m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, buf, &n)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.expected, n, "%d", i)
+	}
+
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{RoundIntegers: true})
+
+	value := decimal.RequireFromString("32767.5")
+	_, err := m.PlanEncode(pgtype.Int2OID, pgtype.BinaryFormatCode, value).Encode(value, nil)
+	var overflowErr *pgxdecimal.IntegerOverflowError
+	require.True(t, errors.As(err, &overflowErr))
+	require.Equal(t, "int2", overflowErr.TypeName)
+
+	// Without RoundIntegers a value that is not an integer is an error.
+	m = pgtype.NewMap()
+	pgxdecimal.Register(m)
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		for _, value := range []interface{}{decimal.RequireFromString("2.5"), pgxdecimal.Decimal(decimal.RequireFromString("1.5"))} {
+			_, err = m.Encode(pgtype.Int8OID, format, value, nil)
+			require.Errorf(t, err, "%d: %v", format, value)
+		}
+	}
+}
+
+func TestValueRoundTripRoundIntegers(t *testing.T) {
+	ctr := connTestRunnerWithOptions(pgxdecimal.Options{RoundIntegers: true, IntRounding: pgxdecimal.HalfEven})
+
+	ctr.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var n2, n4, n8 int64
+		err := conn.QueryRow(ctx, `select $1::int2, $2::int4, $3::int8`,
+			decimal.RequireFromString("1.5"),
+			decimal.RequireFromString("2.5"),
+			decimal.NullDecimal{Decimal: decimal.RequireFromString("-3.5"), Valid: true},
+		).Scan(&n2, &n4, &n8)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n2)
+		require.EqualValues(t, 2, n4)
+		require.EqualValues(t, -4, n8)
+	})
+}
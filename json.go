@@ -0,0 +1,164 @@
+package decimal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// jsonCodec wraps the json and jsonb codecs so numbers are decoded as decimal.Decimal when the target of the value is
+// not typed. Typed targets are unmarshaled by the wrapped codec.
+type jsonCodec struct {
+	pgtype.Codec
+}
+
+func registerJSONCodecs(m *pgtype.Map, opts *Options) {
+	for _, t := range []struct {
+		oid      uint32
+		arrayOID uint32
+	}{
+		{oid: pgtype.JSONOID, arrayOID: pgtype.JSONArrayOID},
+		{oid: pgtype.JSONBOID, arrayOID: pgtype.JSONBArrayOID},
+	} {
+		dt, ok := m.TypeForOID(t.oid)
+		if !ok {
+			continue
+		}
+
+		c, wrapped := dt.Codec.(*jsonCodec)
+		if wrapped == opts.JSONDecimals {
+			continue
+		}
+
+		var codec pgtype.Codec
+		if wrapped {
+			codec = c.Codec
+		} else {
+			codec = &jsonCodec{Codec: dt.Codec}
+		}
+
+		dt = &pgtype.Type{Name: dt.Name, OID: dt.OID, Codec: codec}
+		m.RegisterType(dt)
+
+		if arrayType, ok := m.TypeForOID(t.arrayOID); ok {
+			m.RegisterType(&pgtype.Type{Name: arrayType.Name, OID: arrayType.OID, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+		}
+	}
+}
+
+func (c *jsonCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	switch target.(type) {
+	case *interface{}, *map[string]interface{}:
+		var buf []byte
+		next := c.Codec.PlanScan(m, oid, format, &buf)
+		if next == nil {
+			return nil
+		}
+		return &scanPlanJSONToDecimalJSON{next: next}
+	}
+
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+// scanPlanJSONToDecimalJSON scans the JSON document with next and unmarshals it with numbers as decimal.Decimal.
+type scanPlanJSONToDecimalJSON struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *scanPlanJSONToDecimalJSON) Scan(src []byte, dst interface{}) error {
+	if src == nil {
+		switch dst := dst.(type) {
+		case *interface{}:
+			*dst = nil
+		case *map[string]interface{}:
+			*dst = nil
+		}
+		return nil
+	}
+
+	var buf []byte
+	err := plan.next.Scan(src, &buf)
+	if err != nil {
+		return err
+	}
+
+	v, err := unmarshalDecimalJSON(buf)
+	if err != nil {
+		return err
+	}
+
+	switch dst := dst.(type) {
+	case *interface{}:
+		*dst = v
+	case *map[string]interface{}:
+		if v == nil {
+			*dst = nil
+			return nil
+		}
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot unmarshal JSON %T into %T", v, dst)
+		}
+		*dst = obj
+	}
+
+	return nil
+}
+
+func (c *jsonCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var v interface{}
+	err := c.PlanScan(m, oid, format, &v).Scan(src, &v)
+	return v, err
+}
+
+// unmarshalDecimalJSON unmarshals data like json.Unmarshal into an interface{} except that numbers are decoded as
+// decimal.Decimal instead of float64.
+func unmarshalDecimalJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level JSON value")
+	}
+
+	return jsonNumbersToDecimals(v)
+}
+
+// jsonNumbersToDecimals replaces the json.Number values in v with decimal.Decimal.
+func jsonNumbersToDecimals(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return decimal.NewFromString(v.String())
+	case map[string]interface{}:
+		for k, e := range v {
+			d, err := jsonNumbersToDecimals(e)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = d
+		}
+	case []interface{}:
+		for i, e := range v {
+			d, err := jsonNumbersToDecimals(e)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = d
+		}
+	}
+
+	return v, nil
+}
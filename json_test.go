@@ -0,0 +1,118 @@
+package decimal_test
+
+import (
+	"context"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDecimalsScan(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{JSONDecimals: true})
+
+	doc := `{"amount": 12345678901234567.89, "items": [0.1, {"qty": 3}], "note": "x", "ok": true}`
+
+	for _, tt := range []struct {
+		oid    uint32
+		format int16
+		src    []byte
+	}{
+		{oid: pgtype.JSONOID, format: pgtype.TextFormatCode, src: []byte(doc)},
+		{oid: pgtype.JSONBOID, format: pgtype.TextFormatCode, src: []byte(doc)},
+		{oid: pgtype.JSONBOID, format: pgtype.BinaryFormatCode, src: append([]byte{1}, doc...)},
+	} {
+		var v interface{}
+		err := m.Scan(tt.oid, tt.format, tt.src, &v)
+		require.NoErrorf(t, err, "%d", tt.oid)
+
+		obj := v.(map[string]interface{})
+		require.Equal(t, "12345678901234567.89", obj["amount"].(decimal.Decimal).String())
+		items := obj["items"].([]interface{})
+		require.Equal(t, "0.1", items[0].(decimal.Decimal).String())
+		require.Equal(t, "3", items[1].(map[string]interface{})["qty"].(decimal.Decimal).String())
+		require.Equal(t, "x", obj["note"])
+		require.Equal(t, true, obj["ok"])
+
+		var mv map[string]interface{}
+		err = m.Scan(tt.oid, tt.format, tt.src, &mv)
+		require.NoErrorf(t, err, "%d", tt.oid)
+		require.Equal(t, "12345678901234567.89", mv["amount"].(decimal.Decimal).String())
+
+		var typed struct {
+			Amount float64 `json:"amount"`
+			Note   string  `json:"note"`
+		}
+		err = m.Scan(tt.oid, tt.format, tt.src, &typed)
+		require.NoErrorf(t, err, "%d", tt.oid)
+		require.Equal(t, 12345678901234567.89, typed.Amount)
+		require.Equal(t, "x", typed.Note)
+
+		dt, ok := m.TypeForOID(tt.oid)
+		require.True(t, ok)
+		value, err := dt.Codec.DecodeValue(m, tt.oid, tt.format, tt.src)
+		require.NoErrorf(t, err, "%d", tt.oid)
+		require.Equal(t, "12345678901234567.89", value.(map[string]interface{})["amount"].(decimal.Decimal).String())
+
+		err = m.Scan(tt.oid, tt.format, nil, &mv)
+		require.NoErrorf(t, err, "%d", tt.oid)
+		require.Nil(t, mv)
+	}
+
+	var v interface{}
+	err := m.Scan(pgtype.JSONOID, pgtype.TextFormatCode, []byte(`1e400`), &v)
+	require.NoError(t, err)
+	require.True(t, decimal.New(1, 400).Equal(v.(decimal.Decimal)))
+
+	var mv map[string]interface{}
+	err = m.Scan(pgtype.JSONOID, pgtype.TextFormatCode, []byte(`[1]`), &mv)
+	require.Error(t, err)
+
+	err = m.Scan(pgtype.JSONOID, pgtype.TextFormatCode, []byte(`{} {}`), &v)
+	require.Error(t, err)
+
+	dt, ok := m.TypeForOID(pgtype.JSONBArrayOID)
+	require.True(t, ok)
+	value, err := dt.Codec.DecodeValue(m, pgtype.JSONBArrayOID, pgtype.TextFormatCode, []byte(`{"{\"a\": 1.10}"}`))
+	require.NoError(t, err)
+	require.Equal(t, "1.1", value.([]interface{})[0].(map[string]interface{})["a"].(decimal.Decimal).String())
+}
+
+func TestJSONDecimalsDisabled(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{JSONDecimals: true})
+	pgxdecimal.Register(m)
+
+	var v interface{}
+	err := m.Scan(pgtype.JSONBOID, pgtype.TextFormatCode, []byte(`{"a": 1.5}`), &v)
+	require.NoError(t, err)
+	require.Equal(t, 1.5, v.(map[string]interface{})["a"])
+}
+
+func TestJSONDecimals(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxdecimal.RegisterWithOptions(conn.TypeMap(), pgxdecimal.Options{JSONDecimals: true})
+
+		rows, err := conn.Query(ctx, `select '{"amount": 0.30000000000000004441}'::jsonb, '[1.10, 2]'::json`)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		require.Equal(t, "0.30000000000000004441", values[0].(map[string]interface{})["amount"].(decimal.Decimal).String())
+		require.Equal(t, "1.1", values[1].([]interface{})[0].(decimal.Decimal).String())
+
+		var payload struct {
+			Amount decimal.Decimal `json:"amount"`
+		}
+		err = conn.QueryRow(ctx, `select '{"amount": 12.50}'::jsonb`).Scan(&payload)
+		require.NoError(t, err)
+		require.Equal(t, "12.5", payload.Amount.String())
+	})
+}
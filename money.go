@@ -0,0 +1,232 @@
+package decimal
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// pgx does not support money so its OIDs are defined here.
+const (
+	moneyOID      = 790
+	moneyArrayOID = 791
+)
+
+// RegisterMoney registers money and money[] with conn's type map. The scale of money depends on the lc_monetary
+// setting of the server, so it is loaded from the current session. See RegisterMoneyScale.
+func RegisterMoney(ctx context.Context, conn *pgx.Conn) error {
+	var scale int32
+	err := conn.QueryRow(ctx, "select scale(0::money::numeric)").Scan(&scale)
+	if err != nil {
+		return err
+	}
+
+	RegisterMoneyScale(conn.TypeMap(), scale)
+
+	return nil
+}
+
+// RegisterMoneyScale registers money and money[] with m. scale is the number of fractional digits of the currency of
+// the server's lc_monetary setting, e.g. 2 for USD.
+//
+// money is sent in the binary format, an int64 of scale fractional digits. Encoding a decimal with more than scale
+// decimal places fails with a *ScaleError and encoding a decimal that is out of range fails with an
+// *IntegerOverflowError. Values of money can be scanned into the types supported for numeric in both formats and are
+// decoded as decimal.Decimal in the binary format. The text format depends on lc_monetary (e.g. $1,234.56), so it is
+// decoded as a string like pgx does for unknown types. It is scanned by taking its digits as an integer of scale
+// fractional digits, which is how PostgreSQL formats money in every locale.
+func RegisterMoneyScale(m *pgtype.Map, scale int32) {
+	dt := &pgtype.Type{Name: "money", OID: moneyOID, Codec: &moneyCodec{scale: scale}}
+	m.RegisterType(dt)
+	m.RegisterType(&pgtype.Type{Name: "_money", OID: moneyArrayOID, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+}
+
+type moneyCodec struct {
+	scale int32
+}
+
+func (c *moneyCodec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || format == pgtype.BinaryFormatCode
+}
+
+func (c *moneyCodec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+func (c *moneyCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	if format != pgtype.BinaryFormatCode {
+		return nil
+	}
+
+	if _, ok := value.(pgtype.NumericValuer); ok {
+		return &encodePlanMoneyCodecBinaryNumericValuer{codec: c}
+	}
+
+	return nil
+}
+
+type encodePlanMoneyCodecBinaryNumericValuer struct {
+	codec *moneyCodec
+}
+
+func (plan *encodePlanMoneyCodecBinaryNumericValuer) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	n, err := value.(pgtype.NumericValuer).NumericValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if n.NaN {
+		return nil, fmt.Errorf("cannot encode NaN as money")
+	}
+
+	if n.InfinityModifier != pgtype.Finite {
+		return nil, fmt.Errorf("cannot encode %v as money", n.InfinityModifier)
+	}
+
+	d := decimal.NewFromBigInt(n.Int, n.Exp)
+	cents := d.Shift(plan.codec.scale)
+	if !cents.IsInteger() {
+		return nil, &ScaleError{Value: d, Scale: plan.codec.scale}
+	}
+
+	bi := cents.BigInt()
+	if !bi.IsInt64() {
+		return nil, &IntegerOverflowError{Value: d, TypeName: "money"}
+	}
+
+	return binary.BigEndian.AppendUint64(buf, uint64(bi.Int64())), nil
+}
+
+func (c *moneyCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	if _, ok := target.(pgtype.NumericScanner); !ok {
+		return nil
+	}
+
+	switch format {
+	case pgtype.BinaryFormatCode:
+		return &scanPlanBinaryMoneyToNumericScanner{codec: c}
+	case pgtype.TextFormatCode:
+		return &scanPlanTextMoneyToNumericScanner{codec: c}
+	}
+
+	return nil
+}
+
+type scanPlanBinaryMoneyToNumericScanner struct {
+	codec *moneyCodec
+}
+
+func (plan *scanPlanBinaryMoneyToNumericScanner) Scan(src []byte, dst interface{}) error {
+	s := dst.(pgtype.NumericScanner)
+
+	if src == nil {
+		return s.ScanNumeric(pgtype.Numeric{})
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for money: %v", len(src))
+	}
+
+	cents := int64(binary.BigEndian.Uint64(src))
+	return s.ScanNumeric(pgtype.Numeric{Int: big.NewInt(cents), Exp: -plan.codec.scale, Valid: true})
+}
+
+type scanPlanTextMoneyToNumericScanner struct {
+	codec *moneyCodec
+}
+
+func (plan *scanPlanTextMoneyToNumericScanner) Scan(src []byte, dst interface{}) error {
+	s := dst.(pgtype.NumericScanner)
+
+	if src == nil {
+		return s.ScanNumeric(pgtype.Numeric{})
+	}
+
+	// The currency symbol, separators and sign depend on lc_monetary but there are always scale fractional digits.
+	// Negative values have a minus sign or are in parentheses.
+	var digits []byte
+	negative := false
+	for _, b := range src {
+		switch {
+		case b >= '0' && b <= '9':
+			digits = append(digits, b)
+		case b == '-' || b == '(':
+			negative = true
+		}
+	}
+	if len(digits) == 0 {
+		return fmt.Errorf("invalid money %q", src)
+	}
+
+	n, ok := new(big.Int).SetString(string(digits), 10)
+	if !ok {
+		return fmt.Errorf("invalid money %q", src)
+	}
+	if negative {
+		n.Neg(n)
+	}
+
+	return s.ScanNumeric(pgtype.Numeric{Int: n, Exp: -plan.codec.scale, Valid: true})
+}
+
+func (c *moneyCodec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	if format == pgtype.TextFormatCode {
+		return string(src), nil
+	}
+
+	var d decimal.Decimal
+	err := c.scanDecimal(m, oid, format, src, &d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.StringFixed(c.scale), nil
+}
+
+func (c *moneyCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	// The text format is locale dependent so it is returned as is.
+	if format == pgtype.TextFormatCode {
+		return string(src), nil
+	}
+
+	var d decimal.Decimal
+	err := c.scanDecimal(m, oid, format, src, &d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (c *moneyCodec) scanDecimal(m *pgtype.Map, oid uint32, format int16, src []byte, d *decimal.Decimal) error {
+	// database/sql scanners fall back to DecodeDatabaseSQLValue, so without this check PlanScan would return a plan that
+	// calls this method again for an unknown format.
+	if format != pgtype.BinaryFormatCode {
+		return fmt.Errorf("unknown format code: %v", format)
+	}
+
+	scanPlan := m.PlanScan(oid, format, d)
+	if scanPlan == nil {
+		return fmt.Errorf("PlanScan did not find a plan")
+	}
+
+	return scanPlan.Scan(src, d)
+}
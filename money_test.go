@@ -0,0 +1,149 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// moneyOID is the OID of the PostgreSQL money type.
+const moneyOID = 790
+
+func TestMoneyEncodeScan(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+	pgxdecimal.RegisterMoneyScale(m, 2)
+
+	for i, s := range []string{"0.00", "1234.56", "-0.01", "92233720368547758.07", "-92233720368547758.08"} {
+		d := decimal.RequireFromString(s)
+
+		buf, err := m.Encode(moneyOID, pgtype.BinaryFormatCode, d, nil)
+		require.NoErrorf(t, err, "%d", i)
+
+		var scanned decimal.Decimal
+		err = m.Scan(moneyOID, pgtype.BinaryFormatCode, buf, &scanned)
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, d.Equal(scanned), "%d: %v", i, scanned)
+
+		var fixed pgxdecimal.Fixed
+		fixed.Scale = 2
+		err = m.Scan(moneyOID, pgtype.BinaryFormatCode, buf, &fixed)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, s, fixed.String(), "%d", i)
+	}
+
+	var nd decimal.NullDecimal
+	err := m.Scan(moneyOID, pgtype.BinaryFormatCode, nil, &nd)
+	require.NoError(t, err)
+	require.False(t, nd.Valid)
+
+	value := decimal.RequireFromString("1.005")
+	_, err = m.PlanEncode(moneyOID, pgtype.BinaryFormatCode, value).Encode(value, nil)
+	var scaleErr *pgxdecimal.ScaleError
+	require.True(t, errors.As(err, &scaleErr), err)
+	require.EqualValues(t, 2, scaleErr.Scale)
+
+	value = decimal.RequireFromString("92233720368547758.08")
+	_, err = m.PlanEncode(moneyOID, pgtype.BinaryFormatCode, value).Encode(value, nil)
+	var overflowErr *pgxdecimal.IntegerOverflowError
+	require.True(t, errors.As(err, &overflowErr), err)
+	require.Equal(t, "money", overflowErr.TypeName)
+
+	ds := []decimal.Decimal{decimal.RequireFromString("1.50"), decimal.RequireFromString("-2")}
+	buf, err := m.Encode(moneyOID+1, pgtype.BinaryFormatCode, ds, nil)
+	require.NoError(t, err)
+
+	var scanned []decimal.Decimal
+	err = m.Scan(moneyOID+1, pgtype.BinaryFormatCode, buf, &scanned)
+	require.NoError(t, err)
+	require.Len(t, scanned, 2)
+	require.True(t, scanned[0].Equal(ds[0]))
+	require.True(t, scanned[1].Equal(ds[1]))
+
+	// The text format is scanned by its digits regardless of the locale.
+	for _, tt := range []struct {
+		src      string
+		expected string
+	}{
+		{src: "$1.50", expected: "1.5"},
+		{src: "-$1,234.56", expected: "-1234.56"},
+		{src: "($0.01)", expected: "-0.01"},
+		{src: "1.234,56 €", expected: "1234.56"},
+		{src: "-92233720368547758.08", expected: "-92233720368547758.08"},
+	} {
+		var d decimal.Decimal
+		err = m.Scan(moneyOID, pgtype.TextFormatCode, []byte(tt.src), &d)
+		require.NoErrorf(t, err, "%q", tt.src)
+		require.Truef(t, decimal.RequireFromString(tt.expected).Equal(d), "%q: %v", tt.src, d)
+	}
+
+	err = m.Scan(moneyOID, pgtype.TextFormatCode, []byte("$"), &scanned[0])
+	require.Error(t, err)
+
+	dt, ok := m.TypeForOID(moneyOID)
+	require.True(t, ok)
+	v, err := dt.Codec.DecodeValue(m, moneyOID, pgtype.TextFormatCode, []byte("$1,234.56"))
+	require.NoError(t, err)
+	require.Equal(t, "$1,234.56", v)
+
+	pgxdecimal.RegisterMoneyScale(m, 0)
+	buf, err = m.Encode(moneyOID, pgtype.BinaryFormatCode, decimal.NewFromInt(1500), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0x05, 0xdc}, buf)
+}
+
+func TestMoney(t *testing.T) {
+	pgxtest.RunWithQueryExecModes(context.Background(), t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, `set lc_monetary to 'C'`)
+		require.NoError(t, err)
+
+		require.NoError(t, pgxdecimal.RegisterMoney(ctx, conn))
+
+		rows, err := conn.Query(ctx, `select '1234.56'::money, $1::money, '{1.50,NULL}'::money[]`, decimal.RequireFromString("-0.25"))
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		switch conn.Config().DefaultQueryExecMode {
+		case pgx.QueryExecModeExec, pgx.QueryExecModeSimpleProtocol:
+			// Results are in the locale dependent text format.
+			require.Equal(t, "$1,234.56", values[0])
+			require.Equal(t, "-$0.25", values[1])
+			require.Equal(t, []interface{}{"$1.50", nil}, values[2])
+		default:
+			require.True(t, decimal.RequireFromString("1234.56").Equal(values[0].(decimal.Decimal)))
+			require.True(t, decimal.RequireFromString("-0.25").Equal(values[1].(decimal.Decimal)))
+			require.True(t, decimal.RequireFromString("1.5").Equal(values[2].([]interface{})[0].(decimal.Decimal)))
+			require.Nil(t, values[2].([]interface{})[1])
+		}
+
+		var ds []decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select '{-1234.56,NULL}'::money[]`).Scan(&ds)
+		require.NoError(t, err)
+		require.Len(t, ds, 2)
+		require.True(t, decimal.RequireFromString("-1234.56").Equal(ds[0].Decimal))
+		require.False(t, ds[1].Valid)
+
+		var s string
+		err = conn.QueryRow(ctx, `select $1::money::numeric::text`, decimal.RequireFromString("12.3")).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "12.30", s)
+
+		// Without parameter OIDs the decimal is sent as numeric and PostgreSQL rounds it.
+		if conn.Config().DefaultQueryExecMode != pgx.QueryExecModeExec && conn.Config().DefaultQueryExecMode != pgx.QueryExecModeSimpleProtocol {
+			_, err = conn.Exec(ctx, `select $1::money`, decimal.RequireFromString("0.001"))
+			var scaleErr *pgxdecimal.ScaleError
+			require.True(t, errors.As(err, &scaleErr), err)
+		}
+	})
+}
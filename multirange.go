@@ -0,0 +1,320 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PostgreSQL 14 added multirange types. pgx does not support them yet so their OIDs are defined here.
+const (
+	nummultirangeOID      = 4532
+	nummultirangeArrayOID = 6151
+)
+
+// Multirange is a nummultirange, an ordered list of non-overlapping numranges. A nil Multirange is NULL and an empty
+// non-nil Multirange is the empty multirange. nummultirange is registered by RegisterMultirange.
+type Multirange []Range
+
+// RegisterMultirange registers nummultirange and nummultirange[] with conn's type map if the server is PostgreSQL 14 or
+// later. Older servers do not have multirange types so nothing is registered. Register must be called first.
+func RegisterMultirange(conn *pgx.Conn) error {
+	serverVersion, err := strconv.ParseInt(conn.PgConn().ParameterStatus("server_version_num"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("cannot parse server_version_num: %w", err)
+	}
+
+	if serverVersion >= 140000 {
+		RegisterMultirangeTypes(conn.TypeMap())
+	}
+
+	return nil
+}
+
+// RegisterMultirangeTypes registers nummultirange and nummultirange[] with m regardless of the server version. Register
+// must be called first. Use RegisterMultirange to only register them if the server supports them.
+func RegisterMultirangeTypes(m *pgtype.Map) {
+	dt := &pgtype.Type{Name: "nummultirange", OID: nummultirangeOID, Codec: &multirangeCodec{rangeOID: pgtype.NumrangeOID}}
+	m.RegisterType(dt)
+	m.RegisterType(&pgtype.Type{Name: "_nummultirange", OID: nummultirangeArrayOID, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+
+	registerDefaultPgTypeVariants(m, "nummultirange", "_nummultirange", Multirange{})
+}
+
+// multirangeCodec is the codec for a multirange whose ranges are of type rangeOID.
+type multirangeCodec struct {
+	rangeOID uint32
+}
+
+func (c *multirangeCodec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || format == pgtype.BinaryFormatCode
+}
+
+func (c *multirangeCodec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+func (c *multirangeCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	if _, ok := value.(Multirange); !ok {
+		return nil
+	}
+
+	rangePlan := m.PlanEncode(c.rangeOID, format, Range{})
+	if rangePlan == nil {
+		return nil
+	}
+
+	switch format {
+	case pgtype.BinaryFormatCode:
+		return &encodePlanMultirangeToBinary{rangePlan: rangePlan}
+	case pgtype.TextFormatCode:
+		return &encodePlanMultirangeToText{rangePlan: rangePlan}
+	}
+
+	return nil
+}
+
+type encodePlanMultirangeToBinary struct {
+	rangePlan pgtype.EncodePlan
+}
+
+func (plan *encodePlanMultirangeToBinary) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	mr := value.(Multirange)
+	if mr == nil {
+		return nil, nil
+	}
+
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(mr)))
+	for _, r := range mr {
+		sp := len(buf)
+		buf = binary.BigEndian.AppendUint32(buf, 0)
+
+		buf, err = plan.rangePlan.Encode(r, buf)
+		if err != nil {
+			return nil, err
+		}
+		if buf == nil {
+			return nil, fmt.Errorf("multirange cannot contain NULL ranges")
+		}
+
+		binary.BigEndian.PutUint32(buf[sp:], uint32(len(buf)-sp-4))
+	}
+
+	return buf, nil
+}
+
+type encodePlanMultirangeToText struct {
+	rangePlan pgtype.EncodePlan
+}
+
+func (plan *encodePlanMultirangeToText) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	mr := value.(Multirange)
+	if mr == nil {
+		return nil, nil
+	}
+
+	buf = append(buf, '{')
+	for i, r := range mr {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+
+		buf, err = plan.rangePlan.Encode(r, buf)
+		if err != nil {
+			return nil, err
+		}
+		if buf == nil {
+			return nil, fmt.Errorf("multirange cannot contain NULL ranges")
+		}
+	}
+	buf = append(buf, '}')
+
+	return buf, nil
+}
+
+func (c *multirangeCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	if _, ok := target.(*Multirange); !ok {
+		return nil
+	}
+
+	var r Range
+	rangePlan := m.PlanScan(c.rangeOID, format, &r)
+	if rangePlan == nil {
+		return nil
+	}
+
+	switch format {
+	case pgtype.BinaryFormatCode:
+		return &scanPlanBinaryMultirangeToMultirange{rangePlan: rangePlan}
+	case pgtype.TextFormatCode:
+		return &scanPlanTextMultirangeToMultirange{rangePlan: rangePlan}
+	}
+
+	return nil
+}
+
+type scanPlanBinaryMultirangeToMultirange struct {
+	rangePlan pgtype.ScanPlan
+}
+
+func (plan *scanPlanBinaryMultirangeToMultirange) Scan(src []byte, dst interface{}) error {
+	mr := dst.(*Multirange)
+
+	if src == nil {
+		*mr = nil
+		return nil
+	}
+
+	if len(src) < 4 {
+		return fmt.Errorf("invalid length for multirange: %v", len(src))
+	}
+	count := int(binary.BigEndian.Uint32(src))
+	rp := 4
+
+	// Each range is prefixed by its length so a count that does not fit in src is invalid. Check it before allocating.
+	if count > (len(src)-rp)/4 {
+		return fmt.Errorf("invalid multirange count %d for length %d", count, len(src))
+	}
+
+	ranges := make(Multirange, count)
+	for i := range ranges {
+		if len(src[rp:]) < 4 {
+			return fmt.Errorf("invalid length for multirange: %v", len(src))
+		}
+		rangeLen := int(int32(binary.BigEndian.Uint32(src[rp:])))
+		rp += 4
+
+		if rangeLen < 0 || len(src[rp:]) < rangeLen {
+			return fmt.Errorf("invalid length for multirange: %v", len(src))
+		}
+
+		err := plan.rangePlan.Scan(src[rp:rp+rangeLen], &ranges[i])
+		if err != nil {
+			return err
+		}
+		rp += rangeLen
+	}
+
+	*mr = ranges
+	return nil
+}
+
+type scanPlanTextMultirangeToMultirange struct {
+	rangePlan pgtype.ScanPlan
+}
+
+func (plan *scanPlanTextMultirangeToMultirange) Scan(src []byte, dst interface{}) error {
+	mr := dst.(*Multirange)
+
+	if src == nil {
+		*mr = nil
+		return nil
+	}
+
+	texts, err := parseMultirangeText(string(src))
+	if err != nil {
+		return err
+	}
+
+	ranges := make(Multirange, len(texts))
+	for i, text := range texts {
+		err := plan.rangePlan.Scan([]byte(text), &ranges[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	*mr = ranges
+	return nil
+}
+
+// parseMultirangeText splits the text format of a multirange, e.g. {[1,2),[3,4)}, into the text of its ranges.
+func parseMultirangeText(s string) ([]string, error) {
+	invalid := fmt.Errorf("invalid multirange %q", s)
+
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, invalid
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+
+	texts := []string{}
+	for s != "" {
+		var n int
+		if len(s) >= 5 && strings.EqualFold(s[:5], "empty") {
+			n = 5
+		} else {
+			if s[0] != '[' && s[0] != '(' {
+				return nil, invalid
+			}
+
+			inQuotes := false
+			for i := 1; i < len(s) && n == 0; i++ {
+				switch {
+				case s[i] == '\\' && inQuotes:
+					i++
+				case s[i] == '"':
+					inQuotes = !inQuotes
+				case (s[i] == ']' || s[i] == ')') && !inQuotes:
+					n = i + 1
+				}
+			}
+			if n == 0 {
+				return nil, invalid
+			}
+		}
+
+		texts = append(texts, s[:n])
+
+		s = strings.TrimSpace(s[n:])
+		if s == "" {
+			break
+		}
+		if s[0] != ',' {
+			return nil, invalid
+		}
+		s = strings.TrimSpace(s[1:])
+		if s == "" {
+			return nil, invalid
+		}
+	}
+
+	return texts, nil
+}
+
+func (c *multirangeCodec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	switch format {
+	case pgtype.TextFormatCode:
+		return string(src), nil
+	case pgtype.BinaryFormatCode:
+		buf := make([]byte, len(src))
+		copy(buf, src)
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("unknown format code %d", format)
+	}
+}
+
+func (c *multirangeCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var mr Multirange
+	plan := c.PlanScan(m, oid, format, &mr)
+	if plan == nil {
+		return nil, fmt.Errorf("PlanScan did not find a plan")
+	}
+
+	err := plan.Scan(src, &mr)
+	return mr, err
+}
@@ -0,0 +1,138 @@
+package decimal_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// nummultirangeOID is the OID of nummultirange in PostgreSQL 14 and later.
+const nummultirangeOID = 4532
+
+func requireMultirangeEqual(t testing.TB, expected, actual pgxdecimal.Multirange) {
+	require.Equal(t, expected == nil, actual == nil)
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equalf(t, expected[i].LowerType, actual[i].LowerType, "%d", i)
+		require.Equalf(t, expected[i].UpperType, actual[i].UpperType, "%d", i)
+		require.Truef(t, expected[i].Lower.Equal(actual[i].Lower), "%d: %v", i, actual[i].Lower)
+		require.Truef(t, expected[i].Upper.Equal(actual[i].Upper), "%d: %v", i, actual[i].Upper)
+	}
+}
+
+func TestMultirangeEncodeScan(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+	pgxdecimal.RegisterMultirangeTypes(m)
+
+	multiranges := []pgxdecimal.Multirange{
+		{
+			{Lower: decimal.RequireFromString("-1.5"), Upper: decimal.RequireFromString("2"), LowerType: pgtype.Inclusive, UpperType: pgtype.Exclusive, Valid: true},
+			{Lower: decimal.RequireFromString("10.25"), LowerType: pgtype.Exclusive, UpperType: pgtype.Unbounded, Valid: true},
+		},
+		{},
+		nil,
+	}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		for i, mr := range multiranges {
+			buf, err := m.Encode(nummultirangeOID, format, mr, nil)
+			require.NoErrorf(t, err, "%d", i)
+
+			var scanned pgxdecimal.Multirange
+			err = m.Scan(nummultirangeOID, format, buf, &scanned)
+			require.NoErrorf(t, err, "%d", i)
+			requireMultirangeEqual(t, mr, scanned)
+		}
+	}
+
+	buf, err := m.Encode(nummultirangeOID, pgtype.TextFormatCode, multiranges[0], nil)
+	require.NoError(t, err)
+	require.Equal(t, "{[-1.5,2),(10.25,)}", string(buf))
+
+	var scanned pgxdecimal.Multirange
+	err = m.Scan(nummultirangeOID, pgtype.TextFormatCode, []byte(`{ [1,"2.5"] , empty,(,3)}`), &scanned)
+	require.NoError(t, err)
+	require.Len(t, scanned, 3)
+	require.True(t, scanned[0].Upper.Equal(decimal.RequireFromString("2.5")))
+	require.Equal(t, pgtype.Empty, scanned[1].LowerType)
+	require.Equal(t, pgtype.Unbounded, scanned[2].LowerType)
+
+	for _, src := range []string{"", "[1,2)", "{[1,2)", "{[1,2),}", "{[1,2) [3,4)}", "{1}"} {
+		err = m.Scan(nummultirangeOID, pgtype.TextFormatCode, []byte(src), &scanned)
+		require.Errorf(t, err, "%q", src)
+	}
+
+	for _, src := range [][]byte{
+		{0xff, 0xff, 0xff, 0xf0},
+		{0, 0, 0, 2, 0, 0, 0, 0},
+		{0, 0, 0, 1, 0, 0, 0, 9, 1},
+		{0, 0},
+	} {
+		err = m.Scan(nummultirangeOID, pgtype.BinaryFormatCode, src, &scanned)
+		require.Errorf(t, err, "%v", src)
+	}
+
+	dt, ok := m.TypeForValue(pgxdecimal.Multirange{})
+	require.True(t, ok)
+	require.Equal(t, "nummultirange", dt.Name)
+
+	m = pgtype.NewMap()
+	pgxdecimal.Register(m)
+	_, ok = m.TypeForOID(nummultirangeOID)
+	require.False(t, ok)
+}
+
+func TestRegisterMultirange(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		require.NoError(t, pgxdecimal.RegisterMultirange(conn))
+
+		_, ok := conn.TypeMap().TypeForOID(nummultirangeOID)
+		serverVersion, err := strconv.ParseInt(conn.PgConn().ParameterStatus("server_version_num"), 10, 64)
+		require.NoError(t, err)
+		require.Equalf(t, serverVersion >= 140000, ok, "server_version_num %d", serverVersion)
+	})
+}
+
+func TestMultirange(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		skipPostgreSQLVersionLessThan(t, conn, 140000)
+
+		require.NoError(t, pgxdecimal.RegisterMultirange(conn))
+
+		mr := pgxdecimal.Multirange{
+			{Lower: decimal.RequireFromString("-1.5"), Upper: decimal.RequireFromString("2"), LowerType: pgtype.Inclusive, UpperType: pgtype.Exclusive, Valid: true},
+			{Lower: decimal.RequireFromString("10.25"), LowerType: pgtype.Exclusive, UpperType: pgtype.Unbounded, Valid: true},
+		}
+
+		var scanned pgxdecimal.Multirange
+		err := conn.QueryRow(ctx, `select $1::nummultirange`, mr).Scan(&scanned)
+		require.NoError(t, err)
+		requireMultirangeEqual(t, mr, scanned)
+
+		rows, err := conn.Query(ctx, `select '{[1,3), [2,5), empty}'::nummultirange, '{}'::nummultirange, array['{[1,2]}'::nummultirange]`)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		requireMultirangeEqual(t, pgxdecimal.Multirange{
+			{Lower: decimal.NewFromInt(1), Upper: decimal.NewFromInt(5), LowerType: pgtype.Inclusive, UpperType: pgtype.Exclusive, Valid: true},
+		}, values[0].(pgxdecimal.Multirange))
+		requireMultirangeEqual(t, pgxdecimal.Multirange{}, values[1].(pgxdecimal.Multirange))
+		require.IsType(t, pgxdecimal.Multirange{}, values[2].([]interface{})[0])
+
+		var s string
+		err = conn.QueryRow(ctx, `select pg_typeof($1)::text`, mr).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "nummultirange", s)
+	})
+}
@@ -0,0 +1,83 @@
+package decimal
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// RegisterNamed registers T, a named type defined as decimal.Decimal or decimal.NullDecimal, with m. e.g.
+//
+//	type Price decimal.Decimal
+//	type Quantity decimal.NullDecimal
+//
+//	err := pgxdecimal.RegisterNamed[Price](conn.TypeMap())
+//
+// Values and scan targets of type T are converted to decimal.Decimal or decimal.NullDecimal so they behave the same
+// way, including the Options m was registered with. T and its pointer and slice variants are registered as numeric.
+// m must already have been registered with Register or RegisterWithOptions.
+func RegisterNamed[T any](m *pgtype.Map) error {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	var base reflect.Type
+	for _, t := range []reflect.Type{reflect.TypeOf(decimal.Decimal{}), reflect.TypeOf(decimal.NullDecimal{})} {
+		if typ.Kind() == reflect.Struct && typ.ConvertibleTo(t) {
+			base = t
+		}
+	}
+	if base == nil {
+		return fmt.Errorf("%v is not defined as decimal.Decimal or decimal.NullDecimal", typ)
+	}
+
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{tryWrapNamedEncodePlan[T](base)}, m.TryWrapEncodePlanFuncs...)
+	m.TryWrapScanPlanFuncs = append([]pgtype.TryWrapScanPlanFunc{tryWrapNamedScanPlan[T](reflect.PointerTo(base))}, m.TryWrapScanPlanFuncs...)
+
+	var zero T
+	registerDefaultPgTypeVariants(m, "numeric", "_numeric", zero)
+
+	return nil
+}
+
+func tryWrapNamedEncodePlan[T any](base reflect.Type) pgtype.TryWrapEncodePlanFunc {
+	return func(value interface{}) (plan pgtype.WrappedEncodePlanNextSetter, nextValue interface{}, ok bool) {
+		if value, ok := value.(T); ok {
+			return &wrapNamedEncodePlan[T]{base: base}, reflect.ValueOf(value).Convert(base).Interface(), true
+		}
+
+		return nil, nil, false
+	}
+}
+
+type wrapNamedEncodePlan[T any] struct {
+	next pgtype.EncodePlan
+	base reflect.Type
+}
+
+func (plan *wrapNamedEncodePlan[T]) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapNamedEncodePlan[T]) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(reflect.ValueOf(value.(T)).Convert(plan.base).Interface(), buf)
+}
+
+func tryWrapNamedScanPlan[T any](basePtr reflect.Type) pgtype.TryWrapScanPlanFunc {
+	return func(target interface{}) (plan pgtype.WrappedScanPlanNextSetter, nextDst interface{}, ok bool) {
+		if target, ok := target.(*T); ok {
+			return &wrapNamedScanPlan[T]{basePtr: basePtr}, reflect.ValueOf(target).Convert(basePtr).Interface(), true
+		}
+
+		return nil, nil, false
+	}
+}
+
+type wrapNamedScanPlan[T any] struct {
+	next    pgtype.ScanPlan
+	basePtr reflect.Type
+}
+
+func (plan *wrapNamedScanPlan[T]) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapNamedScanPlan[T]) Scan(src []byte, dst interface{}) error {
+	return plan.next.Scan(src, reflect.ValueOf(dst.(*T)).Convert(plan.basePtr).Interface())
+}
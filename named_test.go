@@ -0,0 +1,89 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+type price decimal.Decimal
+
+type quantity decimal.NullDecimal
+
+func TestRegisterNamed(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{StrictFloat: true})
+	require.NoError(t, pgxdecimal.RegisterNamed[price](m))
+	require.NoError(t, pgxdecimal.RegisterNamed[quantity](m))
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		for i, oid := range []uint32{pgtype.NumericOID, pgtype.Int8OID, pgtype.TextOID} {
+			buf, err := m.Encode(oid, format, price(decimal.RequireFromString("42")), nil)
+			require.NoErrorf(t, err, "%d", i)
+
+			var p price
+			err = m.Scan(oid, format, buf, &p)
+			require.NoErrorf(t, err, "%d", i)
+			require.Truef(t, decimal.Decimal(p).Equal(decimal.NewFromInt(42)), "%d: %v", i, p)
+
+			buf, err = m.Encode(oid, format, &quantity{Decimal: decimal.RequireFromString("-7"), Valid: true}, nil)
+			require.NoErrorf(t, err, "%d", i)
+
+			var q quantity
+			err = m.Scan(oid, format, buf, &q)
+			require.NoErrorf(t, err, "%d", i)
+			require.Truef(t, q.Valid && q.Decimal.Equal(decimal.NewFromInt(-7)), "%d: %v", i, q)
+
+			err = m.Scan(oid, format, nil, &q)
+			require.NoErrorf(t, err, "%d", i)
+			require.Falsef(t, q.Valid, "%d", i)
+		}
+
+		// The options m was registered with apply.
+		value := price(decimal.RequireFromString("0.10000000000000000001"))
+		_, err := m.PlanEncode(pgtype.Float8OID, format, value).Encode(value, nil)
+		var inexactErr *pgxdecimal.InexactFloatError
+		require.True(t, errors.As(err, &inexactErr), err)
+	}
+
+	dt, ok := m.TypeForValue([]*price{})
+	require.True(t, ok)
+	require.Equal(t, "_numeric", dt.Name)
+
+	type notDecimal struct{ value int }
+	require.Error(t, pgxdecimal.RegisterNamed[notDecimal](m))
+	require.Error(t, pgxdecimal.RegisterNamed[string](m))
+}
+
+func TestRegisterNamedRoundTrip(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		require.NoError(t, pgxdecimal.RegisterNamed[price](conn.TypeMap()))
+		require.NoError(t, pgxdecimal.RegisterNamed[quantity](conn.TypeMap()))
+
+		var p price
+		var q quantity
+		var ps []price
+		err := conn.QueryRow(ctx, `select $1::numeric, $2::numeric, $3::numeric[]`,
+			price(decimal.RequireFromString("12.50")),
+			quantity{},
+			[]price{price(decimal.RequireFromString("1")), price(decimal.RequireFromString("-0.5"))},
+		).Scan(&p, &q, &ps)
+		require.NoError(t, err)
+		require.True(t, decimal.Decimal(p).Equal(decimal.RequireFromString("12.5")))
+		require.False(t, q.Valid)
+		require.Len(t, ps, 2)
+		require.True(t, decimal.Decimal(ps[1]).Equal(decimal.RequireFromString("-0.5")))
+
+		// The type is inferred from the registered default type.
+		var s string
+		err = conn.QueryRow(ctx, `select pg_typeof($1)::text`, price(decimal.NewFromInt(1))).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "numeric", s)
+	})
+}
@@ -0,0 +1,441 @@
+package decimal
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Options configures the shopspring/decimal integration registered by RegisterWithOptions. The zero value is the
+// default behavior used by Register.
+type Options struct {
+	// StrictFloat forbids silent precision loss between decimals and float8. Encoding a decimal as a float fails with
+	// an *InexactFloatError unless the float converts back to exactly the same decimal, and scanning a float into a
+	// decimal fails with a *FloatScanError.
+	StrictFloat bool
+
+	// FloatScan controls how floats are converted to decimals when they are scanned. The zero value converts to the
+	// shortest decimal that converts back to the same float.
+	FloatScan FloatConversion
+
+	// RoundIntegers allows decimals that are not integers to be encoded as integers by rounding them with
+	// IntRounding. By default encoding a decimal that is not an integer as an integer is an error. Values that are out
+	// of range after rounding fail with an *IntegerOverflowError.
+	RoundIntegers bool
+	IntRounding   RoundingMode
+
+	// TextParsing controls how decimal.Decimal and decimal.NullDecimal are parsed from text, varchar and bpchar
+	// columns. The padding of bpchar values is always ignored.
+	TextParsing TextParsing
+
+	// HstoreNull controls how NULL values are scanned from an hstore into a map[string]decimal.Decimal. Values in an
+	// hstore are parsed according to TextParsing.
+	HstoreNull HstoreNullPolicy
+
+	// JSONDecimals decodes numbers in json and jsonb values as decimal.Decimal instead of float64 when they are scanned
+	// into an interface{} or a map[string]interface{}, including by Rows.Values. Other targets such as structs are
+	// unmarshaled with encoding/json as usual.
+	JSONDecimals bool
+}
+
+// FloatConversionMode selects how a float is converted to a decimal.
+type FloatConversionMode int8
+
+const (
+	// FloatShortest converts to the shortest decimal that converts back to the same float. This is the same as
+	// decimal.NewFromFloat.
+	FloatShortest FloatConversionMode = iota
+
+	// FloatExact converts to the exact binary value of the float. e.g. 0.1 is converted to
+	// 0.1000000000000000055511151231257827021181583404541015625.
+	FloatExact
+
+	// FloatSignificantDigits converts to the exact value of the float rounded to FloatConversion.Digits significant
+	// digits. This hides binary floating point noise such as 0.30000000000000004.
+	FloatSignificantDigits
+
+	// FloatFixedScale converts to the exact value of the float rounded to FloatConversion.Digits decimal places.
+	FloatFixedScale
+)
+
+// FloatConversion describes how a float is converted to a decimal.
+type FloatConversion struct {
+	Mode FloatConversionMode
+
+	// Digits is the number of significant digits for FloatSignificantDigits or the scale for FloatFixedScale. Digits
+	// less than 1 are treated as 1 for FloatSignificantDigits. Ties are rounded away from zero.
+	Digits int32
+}
+
+// Decimal converts f to a decimal. f must be finite.
+func (c FloatConversion) Decimal(f float64) decimal.Decimal {
+	switch c.Mode {
+	case FloatExact:
+		return exactDecimalFromFloat(f)
+	case FloatSignificantDigits:
+		d := exactDecimalFromFloat(f)
+		if d.IsZero() {
+			return d
+		}
+		digits := c.Digits
+		if digits < 1 {
+			digits = 1
+		}
+		// The number of digits before the decimal point, which is negative for values less than 0.1.
+		intDigits := int32(d.NumDigits()) + d.Exponent()
+		return d.Round(digits - intDigits)
+	case FloatFixedScale:
+		return exactDecimalFromFloat(f).Round(c.Digits)
+	default:
+		return decimal.NewFromFloat(f)
+	}
+}
+
+// exactDecimalFromFloat returns the exact value of f. Every finite float is a fraction with a power of 2 denominator so
+// it has an exact decimal representation.
+func exactDecimalFromFloat(f float64) decimal.Decimal {
+	r := new(big.Rat).SetFloat64(f)
+	shift := r.Denom().BitLen() - 1
+	num := new(big.Int).Mul(r.Num(), new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(shift)), nil))
+	return decimal.NewFromBigInt(num, int32(-shift))
+}
+
+// InexactFloatError is returned in StrictFloat mode when a decimal cannot be encoded as a float without losing
+// precision.
+type InexactFloatError struct {
+	Value decimal.Decimal
+
+	// BitSize is the size of the float: 32 or 64.
+	BitSize int
+}
+
+func (e *InexactFloatError) Error() string {
+	return fmt.Sprintf("cannot convert %v to float%d without loss of precision", e.Value, e.BitSize)
+}
+
+// FloatScanError is returned in StrictFloat mode when a float is scanned into a decimal.
+type FloatScanError struct {
+	Float64 float64
+}
+
+func (e *FloatScanError) Error() string {
+	return fmt.Sprintf("cannot scan float %v into decimal in strict mode", e.Float64)
+}
+
+// float64Value returns d as a pgtype.Float8 subject to opts.
+func (opts *Options) float64Value(d decimal.Decimal) (pgtype.Float8, error) {
+	f := d.InexactFloat64()
+
+	if opts.StrictFloat {
+		if math.IsInf(f, 0) || !decimal.NewFromFloat(f).Equal(d) {
+			return pgtype.Float8{}, &InexactFloatError{Value: d, BitSize: 64}
+		}
+	}
+
+	return pgtype.Float8{Float64: f, Valid: true}, nil
+}
+
+// float4Value returns d as a pgtype.Float4 subject to opts.
+func (opts *Options) float4Value(d decimal.Decimal) (pgtype.Float4, error) {
+	f, err := float4Value(d)
+	if err != nil {
+		return pgtype.Float4{}, err
+	}
+
+	if opts.StrictFloat && !decimal.NewFromFloat32(f.Float32).Equal(d) {
+		return pgtype.Float4{}, &InexactFloatError{Value: d, BitSize: 32}
+	}
+
+	return f, nil
+}
+
+// int64Value returns d as a pgtype.Int8 subject to opts.
+func (opts *Options) int64Value(d decimal.Decimal) (pgtype.Int8, error) {
+	if opts.RoundIntegers {
+		d = opts.IntRounding.Round(d, 0)
+	}
+
+	return int64Value(d)
+}
+
+// checkScanFloat64 returns an error if v may not be scanned into a decimal.
+func (opts *Options) checkScanFloat64(v pgtype.Float8) error {
+	if opts.StrictFloat && v.Valid {
+		return &FloatScanError{Float64: v.Float64}
+	}
+
+	return nil
+}
+
+// optionsDecimal is a Decimal that is encoded subject to opts. It must not implement pgtype.TextValuer: pgx uses a
+// TextValuer for every PostgreSQL type when the text format is used, which would bypass the numeric, float and integer
+// conversions. Text types are handled by textCodec instead.
+type optionsDecimal struct {
+	d    Decimal
+	opts *Options
+}
+
+func (d optionsDecimal) NumericValue() (pgtype.Numeric, error) {
+	return d.d.NumericValue()
+}
+
+func (d optionsDecimal) Float64Value() (pgtype.Float8, error) {
+	return d.opts.float64Value(decimal.Decimal(d.d))
+}
+
+func (d optionsDecimal) Float4Value() (pgtype.Float4, error) {
+	return d.opts.float4Value(decimal.Decimal(d.d))
+}
+
+func (d optionsDecimal) Int64Value() (pgtype.Int8, error) {
+	return d.opts.int64Value(decimal.Decimal(d.d))
+}
+
+// optionsNullDecimal is a NullDecimal that is encoded subject to opts. See optionsDecimal.
+type optionsNullDecimal struct {
+	d    NullDecimal
+	opts *Options
+}
+
+func (d optionsNullDecimal) NumericValue() (pgtype.Numeric, error) {
+	return d.d.NumericValue()
+}
+
+func (d optionsNullDecimal) Float64Value() (pgtype.Float8, error) {
+	if !d.d.Valid {
+		return pgtype.Float8{}, nil
+	}
+
+	return d.opts.float64Value(d.d.Decimal)
+}
+
+func (d optionsNullDecimal) Float4Value() (pgtype.Float4, error) {
+	if !d.d.Valid {
+		return pgtype.Float4{}, nil
+	}
+
+	return d.opts.float4Value(d.d.Decimal)
+}
+
+func (d optionsNullDecimal) Int64Value() (pgtype.Int8, error) {
+	if !d.d.Valid {
+		return pgtype.Int8{}, nil
+	}
+
+	return d.opts.int64Value(d.d.Decimal)
+}
+
+// optionsDecimalScanner scans into a Decimal subject to opts. Like optionsDecimal it must not implement
+// pgtype.TextScanner.
+type optionsDecimalScanner struct {
+	d    *Decimal
+	opts *Options
+}
+
+func (d optionsDecimalScanner) ScanNumeric(v pgtype.Numeric) error {
+	return d.d.ScanNumeric(v)
+}
+
+func (d optionsDecimalScanner) ScanFloat64(v pgtype.Float8) error {
+	err := d.opts.checkScanFloat64(v)
+	if err != nil {
+		return err
+	}
+
+	err = d.d.ScanFloat64(v)
+	if err != nil {
+		return err
+	}
+
+	if d.opts.FloatScan.Mode != FloatShortest {
+		*d.d = Decimal(d.opts.FloatScan.Decimal(v.Float64))
+	}
+
+	return nil
+}
+
+func (d optionsDecimalScanner) ScanFloat4(v pgtype.Float4) error {
+	err := d.opts.checkScanFloat64(pgtype.Float8{Float64: float64(v.Float32), Valid: v.Valid})
+	if err != nil {
+		return err
+	}
+
+	err = d.d.ScanFloat4(v)
+	if err != nil {
+		return err
+	}
+
+	if d.opts.FloatScan.Mode != FloatShortest {
+		*d.d = Decimal(d.opts.FloatScan.Decimal(float64(v.Float32)))
+	}
+
+	return nil
+}
+
+func (d optionsDecimalScanner) ScanInt64(v pgtype.Int8) error {
+	return d.d.ScanInt64(v)
+}
+
+// optionsNullDecimalScanner scans into a NullDecimal subject to opts. See optionsDecimalScanner.
+type optionsNullDecimalScanner struct {
+	d    *NullDecimal
+	opts *Options
+}
+
+func (d optionsNullDecimalScanner) ScanNumeric(v pgtype.Numeric) error {
+	return d.d.ScanNumeric(v)
+}
+
+func (d optionsNullDecimalScanner) ScanFloat64(v pgtype.Float8) error {
+	err := d.opts.checkScanFloat64(v)
+	if err != nil {
+		return err
+	}
+
+	err = d.d.ScanFloat64(v)
+	if err != nil {
+		return err
+	}
+
+	if d.d.Valid && d.opts.FloatScan.Mode != FloatShortest {
+		d.d.Decimal = d.opts.FloatScan.Decimal(v.Float64)
+	}
+
+	return nil
+}
+
+func (d optionsNullDecimalScanner) ScanFloat4(v pgtype.Float4) error {
+	err := d.opts.checkScanFloat64(pgtype.Float8{Float64: float64(v.Float32), Valid: v.Valid})
+	if err != nil {
+		return err
+	}
+
+	err = d.d.ScanFloat4(v)
+	if err != nil {
+		return err
+	}
+
+	if d.d.Valid && d.opts.FloatScan.Mode != FloatShortest {
+		d.d.Decimal = d.opts.FloatScan.Decimal(float64(v.Float32))
+	}
+
+	return nil
+}
+
+func (d optionsNullDecimalScanner) ScanInt64(v pgtype.Int8) error {
+	return d.d.ScanInt64(v)
+}
+
+func (opts *Options) tryWrapNumericEncodePlan(value interface{}) (plan pgtype.WrappedEncodePlanNextSetter, nextValue interface{}, ok bool) {
+	switch value := value.(type) {
+	case decimal.Decimal:
+		return &wrapOptionsDecimalEncodePlan{opts: opts}, optionsDecimal{d: Decimal(value), opts: opts}, true
+	case decimal.NullDecimal:
+		return &wrapOptionsNullDecimalEncodePlan{opts: opts}, optionsNullDecimal{d: NullDecimal(value), opts: opts}, true
+	case sql.Null[decimal.Decimal]:
+		return &wrapOptionsSQLNullDecimalEncodePlan{opts: opts}, optionsNullDecimal{d: NullDecimal{Decimal: value.V, Valid: value.Valid}, opts: opts}, true
+	case map[string]decimal.Decimal:
+		return &wrapHstoreDecimalMapEncodePlan{}, hstoreDecimalMap(value), true
+	case map[string]decimal.NullDecimal:
+		return &wrapHstoreNullDecimalMapEncodePlan{}, hstoreNullDecimalMap(value), true
+	}
+
+	return nil, nil, false
+}
+
+type wrapOptionsDecimalEncodePlan struct {
+	next pgtype.EncodePlan
+	opts *Options
+}
+
+func (plan *wrapOptionsDecimalEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapOptionsDecimalEncodePlan) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(optionsDecimal{d: Decimal(value.(decimal.Decimal)), opts: plan.opts}, buf)
+}
+
+type wrapOptionsNullDecimalEncodePlan struct {
+	next pgtype.EncodePlan
+	opts *Options
+}
+
+func (plan *wrapOptionsNullDecimalEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapOptionsNullDecimalEncodePlan) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(optionsNullDecimal{d: NullDecimal(value.(decimal.NullDecimal)), opts: plan.opts}, buf)
+}
+
+type wrapOptionsSQLNullDecimalEncodePlan struct {
+	next pgtype.EncodePlan
+	opts *Options
+}
+
+func (plan *wrapOptionsSQLNullDecimalEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapOptionsSQLNullDecimalEncodePlan) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	n := value.(sql.Null[decimal.Decimal])
+	return plan.next.Encode(optionsNullDecimal{d: NullDecimal{Decimal: n.V, Valid: n.Valid}, opts: plan.opts}, buf)
+}
+
+func (opts *Options) tryWrapNumericScanPlan(target interface{}) (plan pgtype.WrappedScanPlanNextSetter, nextDst interface{}, ok bool) {
+	switch target := target.(type) {
+	case *decimal.Decimal:
+		return &wrapOptionsDecimalScanPlan{opts: opts}, optionsDecimalScanner{d: (*Decimal)(target), opts: opts}, true
+	case *decimal.NullDecimal:
+		return &wrapOptionsNullDecimalScanPlan{opts: opts}, optionsNullDecimalScanner{d: (*NullDecimal)(target), opts: opts}, true
+	case *sql.Null[decimal.Decimal]:
+		return &wrapOptionsSQLNullDecimalScanPlan{opts: opts}, optionsNullDecimalScanner{d: &NullDecimal{}, opts: opts}, true
+	case *map[string]decimal.Decimal:
+		return &wrapOptionsHstoreDecimalMapScanPlan{opts: opts}, optionsHstoreDecimalMapScanner{m: target, opts: opts}, true
+	case *map[string]decimal.NullDecimal:
+		return &wrapOptionsHstoreNullDecimalMapScanPlan{opts: opts}, optionsHstoreNullDecimalMapScanner{m: target, opts: opts}, true
+	case *ScanAdapter:
+		return &wrapOptionsScanAdapterScanPlan{opts: opts}, optionsScanAdapter{a: target, opts: opts}, true
+	}
+
+	return nil, nil, false
+}
+
+type wrapOptionsDecimalScanPlan struct {
+	next pgtype.ScanPlan
+	opts *Options
+}
+
+func (plan *wrapOptionsDecimalScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapOptionsDecimalScanPlan) Scan(src []byte, dst interface{}) error {
+	return plan.next.Scan(src, optionsDecimalScanner{d: (*Decimal)(dst.(*decimal.Decimal)), opts: plan.opts})
+}
+
+type wrapOptionsNullDecimalScanPlan struct {
+	next pgtype.ScanPlan
+	opts *Options
+}
+
+func (plan *wrapOptionsNullDecimalScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapOptionsNullDecimalScanPlan) Scan(src []byte, dst interface{}) error {
+	return plan.next.Scan(src, optionsNullDecimalScanner{d: (*NullDecimal)(dst.(*decimal.NullDecimal)), opts: plan.opts})
+}
+
+type wrapOptionsSQLNullDecimalScanPlan struct {
+	next pgtype.ScanPlan
+	opts *Options
+}
+
+func (plan *wrapOptionsSQLNullDecimalScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapOptionsSQLNullDecimalScanPlan) Scan(src []byte, dst interface{}) error {
+	var d NullDecimal
+	err := plan.next.Scan(src, optionsNullDecimalScanner{d: &d, opts: plan.opts})
+	if err != nil {
+		return err
+	}
+
+	*dst.(*sql.Null[decimal.Decimal]) = sql.Null[decimal.Decimal]{V: d.Decimal, Valid: d.Valid}
+	return nil
+}
@@ -0,0 +1,150 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func connTestRunnerWithOptions(opts pgxdecimal.Options) pgxtest.ConnTestRunner {
+	ctr := pgxtest.DefaultConnTestRunner()
+	ctr.AfterConnect = func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxdecimal.RegisterWithOptions(conn.TypeMap(), opts)
+	}
+	return ctr
+}
+
+func TestStrictFloatEncodePlan(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{StrictFloat: true})
+
+	for i, tt := range []struct {
+		value   interface{}
+		inexact bool
+	}{
+		{value: decimal.RequireFromString("0.1")},
+		{value: decimal.RequireFromString("-123456.123456")},
+		{value: decimal.NullDecimal{}},
+		{value: decimal.RequireFromString("0.10000000000000000001"), inexact: true},
+		{value: decimal.NullDecimal{Decimal: decimal.RequireFromString("9007199254740993"), Valid: true}, inexact: true},
+		{value: decimal.New(1, 400), inexact: true},
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			plan := m.PlanEncode(pgtype.Float8OID, format, tt.value)
+			require.NotNilf(t, plan, "%d", i)
+
+			_, err := plan.Encode(tt.value, nil)
+			if tt.inexact {
+				var inexactErr *pgxdecimal.InexactFloatError
+				require.Truef(t, errors.As(err, &inexactErr), "%d: %v", i, err)
+			} else {
+				require.NoErrorf(t, err, "%d", i)
+			}
+		}
+	}
+}
+
+func TestStrictFloatEncode(t *testing.T) {
+	// Encoding to numeric is not affected.
+	ctr := connTestRunnerWithOptions(pgxdecimal.Options{StrictFloat: true})
+	pgxtest.RunValueRoundTripTests(context.Background(), t, ctr, nil, "numeric", []pgxtest.ValueRoundTripTest{
+		{
+			Param:  decimal.RequireFromString("123456.123456789012345678901234567890"),
+			Result: new(decimal.Decimal),
+			Test:   isExpectedEqDecimal(decimal.RequireFromString("123456.123456789012345678901234567890")),
+		},
+	})
+
+	ctr.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var f float64
+		err := conn.QueryRow(ctx, `select $1::float8`, decimal.RequireFromString("0.1")).Scan(&f)
+		require.NoError(t, err)
+		require.Equal(t, 0.1, f)
+
+		_, err = conn.Exec(ctx, `select $1::float8`, decimal.RequireFromString("0.10000000000000000001"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot convert 0.10000000000000000001 to float64 without loss of precision")
+	})
+}
+
+func TestStrictFloatScan(t *testing.T) {
+	ctr := connTestRunnerWithOptions(pgxdecimal.Options{StrictFloat: true})
+
+	ctr.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var d decimal.Decimal
+		err := conn.QueryRow(ctx, `select 1.5::float8`).Scan(&d)
+		var scanErr *pgxdecimal.FloatScanError
+		require.True(t, errors.As(err, &scanErr))
+		require.Equal(t, 1.5, scanErr.Float64)
+
+		var nd decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select 1.5::float8`).Scan(&nd)
+		require.True(t, errors.As(err, &scanErr))
+
+		err = conn.QueryRow(ctx, `select null::float8`).Scan(&nd)
+		require.NoError(t, err)
+		require.False(t, nd.Valid)
+
+		err = conn.QueryRow(ctx, `select 1.5::numeric, 2::int8`).Scan(&d, &nd)
+		require.NoError(t, err)
+		require.Equal(t, "1.5", d.String())
+		require.Equal(t, "2", nd.Decimal.String())
+	})
+}
+
+func TestFloatConversionDecimal(t *testing.T) {
+	// Variables prevent constant folding from computing an exact sum.
+	pointOne, pointTwo := 0.1, 0.2
+
+	for i, tt := range []struct {
+		conversion pgxdecimal.FloatConversion
+		value      float64
+		expected   string
+	}{
+		{pgxdecimal.FloatConversion{}, 0.1, "0.1"},
+		{pgxdecimal.FloatConversion{}, pointOne + pointTwo, "0.30000000000000004"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatExact}, 0.1, "0.1000000000000000055511151231257827021181583404541015625"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatExact}, -2.5, "-2.5"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatExact}, 1e20, "100000000000000000000"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatSignificantDigits, Digits: 15}, pointOne + pointTwo, "0.3"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatSignificantDigits, Digits: 3}, 123456, "123000"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatSignificantDigits, Digits: 3}, -0.00123456, "-0.00123"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatSignificantDigits, Digits: 2}, 9.96, "10"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatSignificantDigits, Digits: 0}, 0, "0"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatFixedScale, Digits: 2}, 1.005, "1"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatFixedScale, Digits: 2}, 1.125, "1.13"},
+		{pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatFixedScale, Digits: -2}, 12345.6, "12300"},
+	} {
+		actual := tt.conversion.Decimal(tt.value)
+		require.Truef(t, actual.Equal(decimal.RequireFromString(tt.expected)), "%d: expected %v, got %v", i, tt.expected, actual)
+	}
+}
+
+func TestFloatScanConversion(t *testing.T) {
+	ctr := connTestRunnerWithOptions(pgxdecimal.Options{FloatScan: pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatSignificantDigits, Digits: 6}})
+
+	ctr.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var d decimal.Decimal
+		var nd, nullND decimal.NullDecimal
+		var f4 decimal.Decimal
+		err := conn.QueryRow(ctx, `select 0.1::float8 + 0.2::float8, 1234567.0::float8, null::float8, 0.1::float4`).Scan(&d, &nd, &nullND, &f4)
+		require.NoError(t, err)
+		require.Equal(t, "0.3", d.String())
+		require.True(t, nd.Valid)
+		require.Equal(t, "1234570", nd.Decimal.String())
+		require.False(t, nullND.Valid)
+		require.Equal(t, "0.1", f4.String())
+
+		// numeric is not affected.
+		err = conn.QueryRow(ctx, `select 1234567::numeric`).Scan(&d)
+		require.NoError(t, err)
+		require.Equal(t, "1234567", d.String())
+	})
+}
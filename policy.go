@@ -0,0 +1,343 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// RoundingMode determines how a value is rounded to a scale.
+type RoundingMode int8
+
+const (
+	// HalfAwayFromZero rounds to the nearest value and ties away from zero. This is how PostgreSQL rounds numeric
+	// values.
+	HalfAwayFromZero RoundingMode = iota
+
+	// HalfEven rounds to the nearest value and ties to the nearest even digit (banker's rounding).
+	HalfEven
+
+	// TowardZero truncates.
+	TowardZero
+
+	// AwayFromZero rounds any discarded digits up in magnitude.
+	AwayFromZero
+
+	// Ceiling rounds towards positive infinity.
+	Ceiling
+
+	// Floor rounds towards negative infinity.
+	Floor
+)
+
+var roundingModeNames = map[RoundingMode]string{
+	HalfAwayFromZero: "half_away_from_zero",
+	HalfEven:         "half_even",
+	TowardZero:       "toward_zero",
+	AwayFromZero:     "away_from_zero",
+	Ceiling:          "ceiling",
+	Floor:            "floor",
+}
+
+func (m RoundingMode) String() string {
+	if name, ok := roundingModeNames[m]; ok {
+		return name
+	}
+	return "invalid"
+}
+
+// ParseRoundingMode parses the name of a RoundingMode as returned by RoundingMode.String.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	for m, name := range roundingModeNames {
+		if name == s {
+			return m, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown rounding mode %q", s)
+}
+
+// Round rounds d to places decimal places.
+func (m RoundingMode) Round(d decimal.Decimal, places int32) decimal.Decimal {
+	switch m {
+	case HalfEven:
+		return d.RoundBank(places)
+	case TowardZero:
+		return d.RoundDown(places)
+	case AwayFromZero:
+		return d.RoundUp(places)
+	case Ceiling:
+		return d.RoundCeil(places)
+	case Floor:
+		return d.RoundFloor(places)
+	default:
+		return d.Round(places)
+	}
+}
+
+// scanPolicy adjusts values as they are scanned by a ScanAdapter.
+type scanPolicy struct {
+	scale    int32
+	rounding RoundingMode
+	hasScale bool
+
+	// nullAsZero scans NULL as zero instead of failing or producing an invalid decimal.NullDecimal.
+	nullAsZero bool
+
+	// nanAsNull scans NaN as if it were NULL.
+	nanAsNull bool
+}
+
+// ScanAdapter is a scan target that scans into a *decimal.Decimal or *decimal.NullDecimal the same way as scanning into
+// the target directly and then applies per-target policies such as rounding. It implements pgtype.NumericScanner,
+// pgtype.Float64Scanner and pgtype.Int64Scanner with the default options, so it can be scanned into without Register.
+// When Register or RegisterWithOptions has been called the options given to it such as StrictFloat and FloatScan apply
+// before the policies instead.
+// Use Rounded, NaNAsNull and ZeroIfNull to construct a ScanAdapter. They may be nested to combine policies:
+//
+//	err := conn.QueryRow(ctx, "select amount from payments").Scan(pgxdecimal.Rounded(pgxdecimal.ZeroIfNull(&d), 2, pgxdecimal.HalfEven))
+type ScanAdapter struct {
+	target interface{}
+	policy scanPolicy
+	err    error
+}
+
+// adapt returns a new ScanAdapter for target. If target is already a *ScanAdapter a copy is returned so its policy can
+// be extended.
+func adapt(target interface{}) *ScanAdapter {
+	if a, ok := target.(*ScanAdapter); ok {
+		c := *a
+		return &c
+	}
+
+	a := &ScanAdapter{target: target}
+	switch target.(type) {
+	case *decimal.Decimal, *decimal.NullDecimal:
+	default:
+		a.err = fmt.Errorf("cannot apply decimal scan policy to %T", target)
+	}
+
+	return a
+}
+
+func newPolicyScanner(target interface{}, policy scanPolicy) (*ScanAdapter, error) {
+	a := adapt(target)
+	if a.err != nil {
+		return nil, a.err
+	}
+	a.policy = policy
+
+	return a, nil
+}
+
+// Rounded returns a ScanAdapter that rounds values scanned into target to places decimal places with mode. target must
+// be a *decimal.Decimal, *decimal.NullDecimal or *ScanAdapter.
+func Rounded(target interface{}, places int32, mode RoundingMode) *ScanAdapter {
+	a := adapt(target)
+	a.policy.scale = places
+	a.policy.rounding = mode
+	a.policy.hasScale = true
+	return a
+}
+
+// NaNAsNull returns a ScanAdapter that scans NaN into target as if it were NULL. target must be a *decimal.Decimal,
+// *decimal.NullDecimal or *ScanAdapter.
+func NaNAsNull(target interface{}) *ScanAdapter {
+	a := adapt(target)
+	a.policy.nanAsNull = true
+	return a
+}
+
+// ZeroIfNull returns a ScanAdapter that scans NULL into target as zero. target must be a *decimal.Decimal,
+// *decimal.NullDecimal or *ScanAdapter.
+func ZeroIfNull(target interface{}) *ScanAdapter {
+	a := adapt(target)
+	a.policy.nullAsZero = true
+	return a
+}
+
+func (s *ScanAdapter) ScanNumeric(v pgtype.Numeric) error {
+	return optionsScanAdapter{a: s, opts: defaultOptions}.ScanNumeric(v)
+}
+
+func (s *ScanAdapter) ScanFloat64(v pgtype.Float8) error {
+	return optionsScanAdapter{a: s, opts: defaultOptions}.ScanFloat64(v)
+}
+
+func (s *ScanAdapter) ScanInt64(v pgtype.Int8) error {
+	return optionsScanAdapter{a: s, opts: defaultOptions}.ScanInt64(v)
+}
+
+// optionsScanAdapter scans into a ScanAdapter subject to opts.
+type optionsScanAdapter struct {
+	a    *ScanAdapter
+	opts *Options
+}
+
+// scanner returns the scanner for the target of the adapter subject to opts.
+func (s optionsScanAdapter) scanner() interface {
+	pgtype.NumericScanner
+	pgtype.Float64Scanner
+	float4Scanner
+	pgtype.Int64Scanner
+} {
+	switch target := s.a.target.(type) {
+	case *decimal.Decimal:
+		return optionsDecimalScanner{d: (*Decimal)(target), opts: s.opts}
+	default:
+		return optionsNullDecimalScanner{d: (*NullDecimal)(target.(*decimal.NullDecimal)), opts: s.opts}
+	}
+}
+
+func (s optionsScanAdapter) ScanNumeric(v pgtype.Numeric) error {
+	if s.a.err != nil {
+		return s.a.err
+	}
+
+	if s.a.policy.nanAsNull && v.Valid && v.NaN {
+		v = pgtype.Numeric{}
+	}
+
+	if s.a.policy.nullAsZero && !v.Valid {
+		v = pgtype.Numeric{Int: big.NewInt(0), Valid: true}
+	}
+
+	err := s.scanner().ScanNumeric(v)
+	if err != nil {
+		return err
+	}
+
+	s.a.apply()
+	return nil
+}
+
+func (s optionsScanAdapter) ScanFloat64(v pgtype.Float8) error {
+	if s.a.err != nil {
+		return s.a.err
+	}
+
+	if s.a.policy.nanAsNull && v.Valid && math.IsNaN(v.Float64) {
+		v = pgtype.Float8{}
+	}
+
+	if s.a.policy.nullAsZero && !v.Valid {
+		v = pgtype.Float8{Valid: true}
+	}
+
+	err := s.scanner().ScanFloat64(v)
+	if err != nil {
+		return err
+	}
+
+	s.a.apply()
+	return nil
+}
+
+func (s optionsScanAdapter) ScanFloat4(v pgtype.Float4) error {
+	if s.a.err != nil {
+		return s.a.err
+	}
+
+	if s.a.policy.nanAsNull && v.Valid && math.IsNaN(float64(v.Float32)) {
+		v = pgtype.Float4{}
+	}
+
+	if s.a.policy.nullAsZero && !v.Valid {
+		v = pgtype.Float4{Valid: true}
+	}
+
+	err := s.scanner().ScanFloat4(v)
+	if err != nil {
+		return err
+	}
+
+	s.a.apply()
+	return nil
+}
+
+func (s optionsScanAdapter) ScanInt64(v pgtype.Int8) error {
+	if s.a.err != nil {
+		return s.a.err
+	}
+
+	if s.a.policy.nullAsZero && !v.Valid {
+		v = pgtype.Int8{Valid: true}
+	}
+
+	err := s.scanner().ScanInt64(v)
+	if err != nil {
+		return err
+	}
+
+	s.a.apply()
+	return nil
+}
+
+// float8Codec wraps the float8 codec so ScanAdapters are scanned subject to opts. pgx plans a scan with the codec
+// before trying the wrap plans, so without it a ScanAdapter would always scan floats with the default options.
+type float8Codec struct {
+	pgtype.Codec
+	opts *Options
+}
+
+func registerFloat8Codec(m *pgtype.Map, opts *Options) {
+	dt, ok := m.TypeForOID(pgtype.Float8OID)
+	if !ok {
+		return
+	}
+
+	codec := dt.Codec
+	if c, ok := codec.(*float8Codec); ok {
+		codec = c.Codec
+	}
+
+	m.RegisterType(&pgtype.Type{Name: dt.Name, OID: dt.OID, Codec: &float8Codec{Codec: codec, opts: opts}})
+}
+
+func (c *float8Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	if _, ok := target.(*ScanAdapter); ok {
+		return planScanAdapterScan(c.Codec, m, oid, format, c.opts)
+	}
+
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+// planScanAdapterScan plans a scan into a ScanAdapter with codec subject to opts.
+func planScanAdapterScan(codec pgtype.Codec, m *pgtype.Map, oid uint32, format int16, opts *Options) pgtype.ScanPlan {
+	next := codec.PlanScan(m, oid, format, optionsScanAdapter{a: &ScanAdapter{}, opts: opts})
+	if next == nil {
+		return nil
+	}
+
+	return &wrapOptionsScanAdapterScanPlan{next: next, opts: opts}
+}
+
+type wrapOptionsScanAdapterScanPlan struct {
+	next pgtype.ScanPlan
+	opts *Options
+}
+
+func (plan *wrapOptionsScanAdapterScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapOptionsScanAdapterScanPlan) Scan(src []byte, dst interface{}) error {
+	return plan.next.Scan(src, optionsScanAdapter{a: dst.(*ScanAdapter), opts: plan.opts})
+}
+
+// apply rounds the scanned value to the policy scale.
+func (s *ScanAdapter) apply() {
+	if !s.policy.hasScale {
+		return
+	}
+
+	switch target := s.target.(type) {
+	case *decimal.Decimal:
+		*target = s.policy.rounding.Round(*target, s.policy.scale)
+	case *decimal.NullDecimal:
+		if target.Valid {
+			target.Decimal = s.policy.rounding.Round(target.Decimal, s.policy.scale)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundingModeRound(t *testing.T) {
+	for i, tt := range []struct {
+		mode     pgxdecimal.RoundingMode
+		value    string
+		places   int32
+		expected string
+	}{
+		{pgxdecimal.HalfAwayFromZero, "2.125", 2, "2.13"},
+		{pgxdecimal.HalfAwayFromZero, "-2.125", 2, "-2.13"},
+		{pgxdecimal.HalfEven, "2.125", 2, "2.12"},
+		{pgxdecimal.HalfEven, "2.135", 2, "2.14"},
+		{pgxdecimal.TowardZero, "-2.129", 2, "-2.12"},
+		{pgxdecimal.AwayFromZero, "-2.121", 2, "-2.13"},
+		{pgxdecimal.Ceiling, "-2.129", 2, "-2.12"},
+		{pgxdecimal.Floor, "-2.121", 2, "-2.13"},
+		{pgxdecimal.HalfEven, "12500", -3, "12000"},
+	} {
+		actual := tt.mode.Round(decimal.RequireFromString(tt.value), tt.places)
+		require.Truef(t, actual.Equal(decimal.RequireFromString(tt.expected)), "%d: %v: expected %v, got %v", i, tt.mode, tt.expected, actual)
+	}
+
+	for _, mode := range []pgxdecimal.RoundingMode{pgxdecimal.HalfAwayFromZero, pgxdecimal.HalfEven, pgxdecimal.TowardZero, pgxdecimal.AwayFromZero, pgxdecimal.Ceiling, pgxdecimal.Floor} {
+		parsed, err := pgxdecimal.ParseRoundingMode(mode.String())
+		require.NoError(t, err)
+		require.Equal(t, mode, parsed)
+	}
+}
+
+func TestScanAdapters(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var rounded, zero, roundedZero decimal.Decimal
+		var nanNull, roundedNaNNull decimal.NullDecimal
+		err := conn.QueryRow(ctx, `select 2.125::numeric, null::numeric, null::float8, 'NaN'::numeric, 'NaN'::float8`).Scan(
+			pgxdecimal.Rounded(&rounded, 2, pgxdecimal.HalfEven),
+			pgxdecimal.ZeroIfNull(&zero),
+			pgxdecimal.Rounded(pgxdecimal.ZeroIfNull(&roundedZero), 2, pgxdecimal.HalfEven),
+			pgxdecimal.NaNAsNull(&nanNull),
+			pgxdecimal.Rounded(pgxdecimal.NaNAsNull(&roundedNaNNull), 2, pgxdecimal.HalfEven),
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, "2.12", rounded.String())
+		require.True(t, zero.IsZero())
+		require.True(t, roundedZero.IsZero())
+		require.False(t, nanNull.Valid)
+		require.False(t, roundedNaNNull.Valid)
+
+		var fromInt decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select 7::int8`).Scan(pgxdecimal.Rounded(&fromInt, -1, pgxdecimal.HalfAwayFromZero))
+		require.NoError(t, err)
+		require.True(t, fromInt.Valid)
+		require.Equal(t, "10", fromInt.Decimal.String())
+
+		var d decimal.Decimal
+		err = conn.QueryRow(ctx, `select 'NaN'::numeric`).Scan(pgxdecimal.NaNAsNull(&d))
+		require.EqualError(t, err, "can't scan into dest[0]: cannot scan NULL into *decimal.Decimal")
+
+		var f float64
+		err = conn.QueryRow(ctx, `select 1::numeric`).Scan(pgxdecimal.ZeroIfNull(&f))
+		require.EqualError(t, err, "can't scan into dest[0]: cannot apply decimal scan policy to *float64")
+	})
+}
+
+func TestScanAdaptersWithoutRegister(t *testing.T) {
+	m := pgtype.NewMap()
+
+	src, err := m.Encode(pgtype.NumericOID, pgtype.BinaryFormatCode, pgtype.Numeric{Int: big.NewInt(2125), Exp: -3, Valid: true}, nil)
+	require.NoError(t, err)
+
+	var d decimal.Decimal
+	err = m.Scan(pgtype.NumericOID, pgtype.BinaryFormatCode, src, pgxdecimal.Rounded(&d, 2, pgxdecimal.HalfEven))
+	require.NoError(t, err)
+	require.Equal(t, "2.12", d.String())
+
+	var zero decimal.Decimal
+	err = m.Scan(pgtype.NumericOID, pgtype.BinaryFormatCode, nil, pgxdecimal.ZeroIfNull(&zero))
+	require.NoError(t, err)
+	require.True(t, zero.IsZero())
+
+	var nd decimal.NullDecimal
+	err = m.Scan(pgtype.Float8OID, pgtype.TextFormatCode, []byte("NaN"), pgxdecimal.NaNAsNull(&nd))
+	require.NoError(t, err)
+	require.False(t, nd.Valid)
+
+	err = m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, []byte("7"), pgxdecimal.Rounded(&nd, -1, pgxdecimal.HalfAwayFromZero))
+	require.NoError(t, err)
+	require.Equal(t, "10", nd.Decimal.String())
+}
+
+func TestScanAdapterOptions(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{StrictFloat: true})
+
+	src, err := m.Encode(pgtype.Float8OID, pgtype.BinaryFormatCode, 0.1, nil)
+	require.NoError(t, err)
+
+	var d decimal.Decimal
+	var floatScanErr *pgxdecimal.FloatScanError
+	err = m.Scan(pgtype.Float8OID, pgtype.BinaryFormatCode, src, pgxdecimal.Rounded(&d, 2, pgxdecimal.HalfEven))
+	require.True(t, errors.As(err, &floatScanErr), err)
+
+	var nd decimal.NullDecimal
+	err = m.Scan(pgtype.Float8OID, pgtype.TextFormatCode, []byte("0.1"), pgxdecimal.ZeroIfNull(&nd))
+	require.True(t, errors.As(err, &floatScanErr), err)
+
+	err = m.Scan(pgtype.Float4OID, pgtype.TextFormatCode, []byte("0.1"), pgxdecimal.NaNAsNull(&nd))
+	require.True(t, errors.As(err, &floatScanErr), err)
+
+	// Tag policies scan with the same options.
+	var tagged struct {
+		Value decimal.Decimal `pgxdecimal:"scale=2"`
+	}
+	float8, _ := m.TypeForOID(pgtype.Float8OID)
+	dt := &pgtype.Type{Name: "tagged", OID: 100200, Codec: &pgtype.CompositeCodec{Fields: []pgtype.CompositeCodecField{
+		{Name: "value", Type: float8},
+	}}}
+	m.RegisterType(dt)
+	err = m.Scan(dt.OID, pgtype.TextFormatCode, []byte("(0.1)"), &tagged)
+	require.True(t, errors.As(err, &floatScanErr), err)
+
+	m = pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{FloatScan: pgxdecimal.FloatConversion{Mode: pgxdecimal.FloatExact}})
+	err = m.Scan(pgtype.Float8OID, pgtype.BinaryFormatCode, src, pgxdecimal.Rounded(&d, 20, pgxdecimal.TowardZero))
+	require.NoError(t, err)
+	require.Equal(t, "0.10000000000000000555", d.String())
+}
@@ -0,0 +1,126 @@
+package decimal
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Range is a numrange with decimal.Decimal bounds. It is the decimal equivalent of pgtype.Numrange. The bounds of an
+// empty range and unbounded bounds are zero.
+type Range struct {
+	Lower     decimal.Decimal
+	Upper     decimal.Decimal
+	LowerType pgtype.BoundType
+	UpperType pgtype.BoundType
+	Valid     bool
+}
+
+func (r Range) IsNull() bool {
+	return !r.Valid
+}
+
+func (r Range) BoundTypes() (lower, upper pgtype.BoundType) {
+	return r.LowerType, r.UpperType
+}
+
+func (r Range) Bounds() (lower, upper interface{}) {
+	return r.Lower, r.Upper
+}
+
+func (r *Range) ScanNull() error {
+	*r = Range{}
+	return nil
+}
+
+func (r *Range) ScanBounds() (lowerTarget, upperTarget interface{}) {
+	return &r.Lower, &r.Upper
+}
+
+func (r *Range) SetBoundTypes(lower, upper pgtype.BoundType) error {
+	if lower == pgtype.Unbounded || lower == pgtype.Empty {
+		r.Lower = decimal.Decimal{}
+	}
+	if upper == pgtype.Unbounded || upper == pgtype.Empty {
+		r.Upper = decimal.Decimal{}
+	}
+	r.LowerType = lower
+	r.UpperType = upper
+	r.Valid = true
+	return nil
+}
+
+// NullRange is a numrange with decimal.NullDecimal bounds. The bounds of an empty range and unbounded bounds are not
+// valid, so unlike Range they cannot be mistaken for zero.
+type NullRange struct {
+	Lower     decimal.NullDecimal
+	Upper     decimal.NullDecimal
+	LowerType pgtype.BoundType
+	UpperType pgtype.BoundType
+	Valid     bool
+}
+
+func (r NullRange) IsNull() bool {
+	return !r.Valid
+}
+
+func (r NullRange) BoundTypes() (lower, upper pgtype.BoundType) {
+	return r.LowerType, r.UpperType
+}
+
+func (r NullRange) Bounds() (lower, upper interface{}) {
+	return r.Lower, r.Upper
+}
+
+func (r *NullRange) ScanNull() error {
+	*r = NullRange{}
+	return nil
+}
+
+func (r *NullRange) ScanBounds() (lowerTarget, upperTarget interface{}) {
+	return &r.Lower, &r.Upper
+}
+
+func (r *NullRange) SetBoundTypes(lower, upper pgtype.BoundType) error {
+	if lower == pgtype.Unbounded || lower == pgtype.Empty {
+		r.Lower = decimal.NullDecimal{}
+	}
+	if upper == pgtype.Unbounded || upper == pgtype.Empty {
+		r.Upper = decimal.NullDecimal{}
+	}
+	r.LowerType = lower
+	r.UpperType = upper
+	r.Valid = true
+	return nil
+}
+
+// numrangeCodec wraps the numrange codec so numrange values are decoded as Range.
+type numrangeCodec struct {
+	pgtype.Codec
+}
+
+func registerNumrangeCodec(m *pgtype.Map) {
+	dt, ok := m.TypeForOID(pgtype.NumrangeOID)
+	if !ok {
+		return
+	}
+	if _, ok := dt.Codec.(*numrangeCodec); ok {
+		return
+	}
+
+	dt = &pgtype.Type{Name: dt.Name, OID: dt.OID, Codec: &numrangeCodec{Codec: dt.Codec}}
+	m.RegisterType(dt)
+
+	if arrayType, ok := m.TypeForOID(pgtype.NumrangeArrayOID); ok {
+		m.RegisterType(&pgtype.Type{Name: arrayType.Name, OID: arrayType.OID, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+	}
+}
+
+func (c *numrangeCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var r Range
+	err := c.Codec.PlanScan(m, oid, format, &r).Scan(src, &r)
+	return r, err
+}
@@ -0,0 +1,123 @@
+package decimal_test
+
+import (
+	"context"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeEncodeScan(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	ranges := []pgxdecimal.Range{
+		{Lower: decimal.RequireFromString("1.50"), Upper: decimal.RequireFromString("10"), LowerType: pgtype.Inclusive, UpperType: pgtype.Exclusive, Valid: true},
+		{Lower: decimal.RequireFromString("-2.5"), LowerType: pgtype.Exclusive, UpperType: pgtype.Unbounded, Valid: true},
+		{LowerType: pgtype.Empty, UpperType: pgtype.Empty, Valid: true},
+		{},
+	}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		for i, r := range ranges {
+			buf, err := m.Encode(pgtype.NumrangeOID, format, r, nil)
+			require.NoErrorf(t, err, "%d", i)
+
+			var scanned pgxdecimal.Range
+			err = m.Scan(pgtype.NumrangeOID, format, buf, &scanned)
+			require.NoErrorf(t, err, "%d", i)
+			require.Equalf(t, r.Valid, scanned.Valid, "%d", i)
+			require.Equalf(t, r.LowerType, scanned.LowerType, "%d", i)
+			require.Equalf(t, r.UpperType, scanned.UpperType, "%d", i)
+			require.Truef(t, r.Lower.Equal(scanned.Lower), "%d: %v", i, scanned.Lower)
+			require.Truef(t, r.Upper.Equal(scanned.Upper), "%d: %v", i, scanned.Upper)
+
+			var nullRange pgxdecimal.NullRange
+			err = m.Scan(pgtype.NumrangeOID, format, buf, &nullRange)
+			require.NoErrorf(t, err, "%d", i)
+			require.Equalf(t, r.Valid, nullRange.Valid, "%d", i)
+			require.Equalf(t, r.LowerType == pgtype.Inclusive || r.LowerType == pgtype.Exclusive, nullRange.Lower.Valid, "%d", i)
+			require.Equalf(t, r.UpperType == pgtype.Inclusive || r.UpperType == pgtype.Exclusive, nullRange.Upper.Valid, "%d", i)
+		}
+
+		buf, err := m.Encode(pgtype.NumrangeArrayOID, format, ranges[:3], nil)
+		require.NoError(t, err)
+
+		var scanned []pgxdecimal.Range
+		err = m.Scan(pgtype.NumrangeArrayOID, format, buf, &scanned)
+		require.NoError(t, err)
+		require.Len(t, scanned, 3)
+		require.True(t, scanned[0].Lower.Equal(ranges[0].Lower))
+
+		dt, ok := m.TypeForOID(pgtype.NumrangeOID)
+		require.True(t, ok)
+		buf, err = m.Encode(pgtype.NumrangeOID, format, ranges[0], nil)
+		require.NoError(t, err)
+		value, err := dt.Codec.DecodeValue(m, pgtype.NumrangeOID, format, buf)
+		require.NoError(t, err)
+		require.IsType(t, pgxdecimal.Range{}, value)
+	}
+
+	buf, err := m.Encode(pgtype.NumrangeOID, pgtype.TextFormatCode, ranges[0], nil)
+	require.NoError(t, err)
+	require.Equal(t, "[1.50,10)", string(buf))
+
+	for _, value := range []interface{}{pgxdecimal.Range{}, &pgxdecimal.NullRange{}, []pgxdecimal.Range{}} {
+		dt, ok := m.TypeForValue(value)
+		require.True(t, ok)
+		require.Contains(t, []string{"numrange", "_numrange"}, dt.Name)
+	}
+}
+
+func TestRange(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		r := pgxdecimal.Range{
+			Lower:     decimal.RequireFromString("1.50"),
+			Upper:     decimal.RequireFromString("10"),
+			LowerType: pgtype.Inclusive,
+			UpperType: pgtype.Exclusive,
+			Valid:     true,
+		}
+
+		rows, err := conn.Query(ctx, `select $1::numrange, '(,5]'::numrange, 'empty'::numrange, array['[1,2)'::numrange]`, r)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		scanned := values[0].(pgxdecimal.Range)
+		require.True(t, scanned.Lower.Equal(r.Lower))
+		require.True(t, scanned.Upper.Equal(r.Upper))
+		require.Equal(t, pgtype.Inclusive, scanned.LowerType)
+		require.Equal(t, pgtype.Exclusive, scanned.UpperType)
+
+		unbounded := values[1].(pgxdecimal.Range)
+		require.Equal(t, pgtype.Unbounded, unbounded.LowerType)
+		require.Equal(t, pgtype.Inclusive, unbounded.UpperType)
+		require.True(t, unbounded.Upper.Equal(decimal.NewFromInt(5)))
+
+		require.Equal(t, pgtype.Empty, values[2].(pgxdecimal.Range).LowerType)
+		require.IsType(t, pgxdecimal.Range{}, values[3].([]interface{})[0])
+
+		var nullRanges []pgxdecimal.NullRange
+		err = conn.QueryRow(ctx, `select $1::numrange[]`, []pgxdecimal.NullRange{
+			{Lower: decimal.NullDecimal{Decimal: decimal.NewFromInt(1), Valid: true}, LowerType: pgtype.Inclusive, UpperType: pgtype.Unbounded, Valid: true},
+		}).Scan(&nullRanges)
+		require.NoError(t, err)
+		require.Len(t, nullRanges, 1)
+		require.True(t, nullRanges[0].Lower.Valid)
+		require.False(t, nullRanges[0].Upper.Valid)
+
+		// The type is inferred from the registered default type.
+		var s string
+		err = conn.QueryRow(ctx, `select pg_typeof($1)::text`, r).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "numrange", s)
+	})
+}
@@ -0,0 +1,88 @@
+package decimal_test
+
+import (
+	"context"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordDecodeValue(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	b := pgtype.NewCompositeBinaryBuilder(m, nil)
+	b.AppendValue(pgtype.NumericOID, decimal.RequireFromString("1.50"))
+	b.AppendValue(pgtype.TextOID, "usd")
+	b.AppendValue(pgtype.NumericOID, nil)
+	src, err := b.Finish()
+	require.NoError(t, err)
+
+	var v interface{}
+	err = m.Scan(pgtype.RecordOID, pgtype.BinaryFormatCode, src, &v)
+	require.NoError(t, err)
+
+	values, ok := v.([]interface{})
+	require.True(t, ok)
+	require.Len(t, values, 3)
+	require.True(t, decimal.RequireFromString("1.5").Equal(values[0].(decimal.Decimal)))
+	require.Equal(t, "usd", values[1])
+	require.Nil(t, values[2])
+
+	var amount decimal.Decimal
+	var currency string
+	var fee decimal.NullDecimal
+	err = m.Scan(pgtype.RecordOID, pgtype.BinaryFormatCode, src, pgtype.CompositeFields{&amount, &currency, &fee})
+	require.NoError(t, err)
+	require.True(t, amount.Equal(decimal.RequireFromString("1.5")))
+	require.Equal(t, "usd", currency)
+	require.False(t, fee.Valid)
+
+	var s struct {
+		Amount   decimal.Decimal `pgxdecimal:"scale=0,round=ceiling"`
+		Currency string
+		Fee      decimal.Decimal `pgxdecimal:"null=zero"`
+	}
+	err = m.Scan(pgtype.RecordOID, pgtype.BinaryFormatCode, src, &s)
+	require.NoError(t, err)
+	require.Equal(t, "2", s.Amount.String())
+	require.Equal(t, "usd", s.Currency)
+	require.True(t, s.Fee.IsZero())
+}
+
+func TestRecord(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, err := conn.Query(ctx, `select row(12.50::numeric, 'usd'::text, null::numeric)`)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		rows.Close()
+		require.NoError(t, rows.Err())
+
+		require.Equal(t, []interface{}{decimal.RequireFromString("12.50"), "usd", nil}, values[0])
+
+		var amount decimal.Decimal
+		var currency string
+		var fee decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select row(12.50::numeric, 'usd'::text, null::numeric)`).Scan(pgtype.CompositeFields{&amount, &currency, &fee})
+		require.NoError(t, err)
+		require.True(t, amount.Equal(decimal.RequireFromString("12.5")))
+		require.Equal(t, "usd", currency)
+		require.False(t, fee.Valid)
+
+		var s struct {
+			Amount   decimal.Decimal
+			Currency string
+			Fee      decimal.NullDecimal `pgxdecimal:"nan=null"`
+		}
+		err = conn.QueryRow(ctx, `select row(12.50::numeric, 'usd'::text, 'NaN'::numeric)`).Scan(&s)
+		require.NoError(t, err)
+		require.True(t, s.Amount.Equal(decimal.RequireFromString("12.5")))
+		require.False(t, s.Fee.Valid)
+	})
+}
@@ -0,0 +1,104 @@
+package decimal
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// ScaleError is returned when a decimal cannot be encoded as a scaled integer because it has more decimal places than
+// the scale.
+type ScaleError struct {
+	Value decimal.Decimal
+	Scale int32
+}
+
+func (e *ScaleError) Error() string {
+	return fmt.Sprintf("cannot encode %v as an integer with scale %d without losing digits", e.Value, e.Scale)
+}
+
+// ScaledInt stores a decimal in an integer column as the decimal multiplied by 10^scale. e.g. with a scale of 2 an
+// amount of 12.34 is stored as 1234. Use Scaled to construct a ScaledInt.
+type ScaledInt struct {
+	value interface{}
+	scale int32
+	err   error
+}
+
+// Scaled returns a ScaledInt for value with scale. As a query argument value must be a decimal.Decimal,
+// decimal.NullDecimal or a pointer to one and it is encoded as an integer. As a scan target value must be a
+// *decimal.Decimal or *decimal.NullDecimal and integers are scanned into it divided by 10^scale.
+//
+// Encoding a decimal with more than scale decimal places fails with a *ScaleError. Encoding a decimal that is out of
+// range for the integer type fails with an *IntegerOverflowError.
+//
+//	_, err := conn.Exec(ctx, "insert into payments (amount_cents) values ($1)", pgxdecimal.Scaled(amount, 2))
+//	err = conn.QueryRow(ctx, "select amount_cents from payments").Scan(pgxdecimal.Scaled(&amount, 2))
+func Scaled(value interface{}, scale int32) *ScaledInt {
+	s := &ScaledInt{value: value, scale: scale}
+	switch value.(type) {
+	case decimal.Decimal, decimal.NullDecimal, *decimal.Decimal, *decimal.NullDecimal:
+	default:
+		s.err = fmt.Errorf("cannot use %T as a scaled integer", value)
+	}
+
+	return s
+}
+
+func (s *ScaledInt) Int64Value() (pgtype.Int8, error) {
+	if s.err != nil {
+		return pgtype.Int8{}, s.err
+	}
+
+	var d decimal.NullDecimal
+	switch value := s.value.(type) {
+	case decimal.Decimal:
+		d = decimal.NullDecimal{Decimal: value, Valid: true}
+	case decimal.NullDecimal:
+		d = value
+	case *decimal.Decimal:
+		if value != nil {
+			d = decimal.NullDecimal{Decimal: *value, Valid: true}
+		}
+	case *decimal.NullDecimal:
+		if value != nil {
+			d = *value
+		}
+	}
+
+	if !d.Valid {
+		return pgtype.Int8{}, nil
+	}
+
+	n := d.Decimal.Shift(s.scale)
+	if !n.IsInteger() {
+		return pgtype.Int8{}, &ScaleError{Value: d.Decimal, Scale: s.scale}
+	}
+
+	return int64Value(n)
+}
+
+func (s *ScaledInt) ScanInt64(v pgtype.Int8) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	switch target := s.value.(type) {
+	case *decimal.Decimal:
+		if !v.Valid {
+			return fmt.Errorf("cannot scan NULL into *decimal.Decimal")
+		}
+		*target = decimal.New(v.Int64, -s.scale)
+	case *decimal.NullDecimal:
+		if !v.Valid {
+			*target = decimal.NullDecimal{}
+			return nil
+		}
+		*target = decimal.NullDecimal{Decimal: decimal.New(v.Int64, -s.scale), Valid: true}
+	default:
+		return fmt.Errorf("cannot scan into %T", s.value)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaledRoundTrip(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, `create temporary table pgxdecimal_scaled (id int8, amount_cents int8, amount_thousands int4)`)
+		require.NoError(t, err)
+
+		_, err = conn.Exec(ctx, `insert into pgxdecimal_scaled values (1, $1, $2), (2, $3, $4)`,
+			pgxdecimal.Scaled(decimal.RequireFromString("12.34"), 2),
+			pgxdecimal.Scaled(decimal.RequireFromString("-12000"), -3),
+			pgxdecimal.Scaled(decimal.NullDecimal{}, 2),
+			pgxdecimal.Scaled(&decimal.NullDecimal{Decimal: decimal.RequireFromString("1e9"), Valid: true}, -3),
+		)
+		require.NoError(t, err)
+
+		var cents, thousands int64
+		err = conn.QueryRow(ctx, `select amount_cents, amount_thousands from pgxdecimal_scaled where id = 1`).Scan(&cents, &thousands)
+		require.NoError(t, err)
+		require.EqualValues(t, 1234, cents)
+		require.EqualValues(t, -12, thousands)
+
+		var amount decimal.Decimal
+		var amountThousands decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select amount_cents, amount_thousands from pgxdecimal_scaled where id = 1`).Scan(
+			pgxdecimal.Scaled(&amount, 2),
+			pgxdecimal.Scaled(&amountThousands, -3),
+		)
+		require.NoError(t, err)
+		require.True(t, amount.Equal(decimal.RequireFromString("12.34")), amount)
+		require.True(t, amountThousands.Valid && amountThousands.Decimal.Equal(decimal.RequireFromString("-12000")), amountThousands)
+
+		var nullAmount decimal.NullDecimal
+		err = conn.QueryRow(ctx, `select amount_cents, amount_thousands from pgxdecimal_scaled where id = 2`).Scan(
+			pgxdecimal.Scaled(&nullAmount, 2),
+			pgxdecimal.Scaled(&amountThousands, -3),
+		)
+		require.NoError(t, err)
+		require.False(t, nullAmount.Valid)
+		require.True(t, amountThousands.Valid && amountThousands.Decimal.Equal(decimal.RequireFromString("1e9")), amountThousands)
+
+		err = conn.QueryRow(ctx, `select amount_cents from pgxdecimal_scaled where id = 2`).Scan(pgxdecimal.Scaled(&amount, 2))
+		require.Error(t, err)
+
+		_, err = conn.Exec(ctx, `insert into pgxdecimal_scaled (amount_cents) values ($1)`, pgxdecimal.Scaled(decimal.RequireFromString("12.345"), 2))
+		var scaleErr *pgxdecimal.ScaleError
+		require.True(t, errors.As(err, &scaleErr), err)
+	})
+}
+
+func TestScaledEncode(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	for i, tt := range []struct {
+		oid      uint32
+		value    interface{}
+		expected string
+	}{
+		{oid: pgtype.Int8OID, value: pgxdecimal.Scaled(decimal.RequireFromString("12.34"), 2), expected: "1234"},
+		{oid: pgtype.Int8OID, value: pgxdecimal.Scaled(decimal.RequireFromString("-12.3"), 2), expected: "-1230"},
+		{oid: pgtype.Int8OID, value: pgxdecimal.Scaled(decimal.RequireFromString("12000"), -3), expected: "12"},
+		{oid: pgtype.Int4OID, value: pgxdecimal.Scaled(&decimal.NullDecimal{Decimal: decimal.RequireFromString("0.01"), Valid: true}, 2), expected: "1"},
+		{oid: pgtype.Int2OID, value: pgxdecimal.Scaled(decimal.NullDecimal{}, 2)},
+	} {
+		buf, err := m.Encode(tt.oid, pgtype.TextFormatCode, tt.value, nil)
+		require.NoErrorf(t, err, "%d", i)
+		if tt.expected == "" {
+			require.Nilf(t, buf, "%d", i)
+		} else {
+			require.Equalf(t, tt.expected, string(buf), "%d", i)
+		}
+	}
+
+	for i, tt := range []struct {
+		oid      uint32
+		value    interface{}
+		overflow bool
+	}{
+		{oid: pgtype.Int8OID, value: pgxdecimal.Scaled(decimal.RequireFromString("12.345"), 2)},
+		{oid: pgtype.Int8OID, value: pgxdecimal.Scaled(decimal.RequireFromString("12345"), -3)},
+		{oid: pgtype.Int8OID, value: pgxdecimal.Scaled(decimal.RequireFromString("92233720368547758.08"), 2), overflow: true},
+		{oid: pgtype.Int4OID, value: pgxdecimal.Scaled(decimal.RequireFromString("21474836.48"), 2), overflow: true},
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			_, err := m.PlanEncode(tt.oid, format, tt.value).Encode(tt.value, nil)
+			if tt.overflow {
+				var overflowErr *pgxdecimal.IntegerOverflowError
+				require.Truef(t, errors.As(err, &overflowErr), "%d: %v", i, err)
+			} else {
+				var scaleErr *pgxdecimal.ScaleError
+				require.Truef(t, errors.As(err, &scaleErr), "%d: %v", i, err)
+			}
+		}
+	}
+
+	_, err := m.Encode(pgtype.Int8OID, pgtype.BinaryFormatCode, pgxdecimal.Scaled(1.5, 2), nil)
+	require.Error(t, err)
+}
+
+func TestScaledScan(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	var d decimal.Decimal
+	err := m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, []byte("-1234"), pgxdecimal.Scaled(&d, 2))
+	require.NoError(t, err)
+	require.True(t, d.Equal(decimal.RequireFromString("-12.34")), d)
+
+	err = m.Scan(pgtype.Int4OID, pgtype.TextFormatCode, []byte("12"), pgxdecimal.Scaled(&d, -3))
+	require.NoError(t, err)
+	require.True(t, d.Equal(decimal.RequireFromString("12000")), d)
+
+	err = m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, nil, pgxdecimal.Scaled(&d, 2))
+	require.Error(t, err)
+
+	nd := decimal.NullDecimal{Decimal: decimal.NewFromInt(1), Valid: true}
+	err = m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, nil, pgxdecimal.Scaled(&nd, 2))
+	require.NoError(t, err)
+	require.False(t, nd.Valid)
+
+	err = m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, []byte("1"), pgxdecimal.Scaled(d, 2))
+	require.Error(t, err)
+}
@@ -0,0 +1,62 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Scan implements the database/sql Scanner interface. src may be a string, []byte, int64 or float64. NULL, NaN and
+// invalid text are rejected the same way as by ScanNumeric and text columns.
+func (d *Decimal) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return fmt.Errorf("cannot scan NULL into *decimal.Decimal")
+	case string:
+		return d.scanText(src)
+	case []byte:
+		return d.scanText(string(src))
+	case int64:
+		return d.ScanInt64(pgtype.Int8{Int64: src, Valid: true})
+	case float64:
+		return d.ScanFloat64(pgtype.Float8{Float64: src, Valid: true})
+	}
+
+	return fmt.Errorf("cannot scan %T into *decimal.Decimal", src)
+}
+
+// Value implements the database/sql/driver Valuer interface. The decimal is returned as a string so no precision is
+// lost.
+func (d Decimal) Value() (driver.Value, error) {
+	return decimal.Decimal(d).String(), nil
+}
+
+// Scan implements the database/sql Scanner interface. See Decimal.Scan.
+func (d *NullDecimal) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*d = NullDecimal{}
+		return nil
+	case string:
+		return d.scanText(src)
+	case []byte:
+		return d.scanText(string(src))
+	case int64:
+		return d.ScanInt64(pgtype.Int8{Int64: src, Valid: true})
+	case float64:
+		return d.ScanFloat64(pgtype.Float8{Float64: src, Valid: true})
+	}
+
+	return fmt.Errorf("cannot scan %T into *decimal.NullDecimal", src)
+}
+
+// Value implements the database/sql/driver Valuer interface. See Decimal.Value.
+func (d NullDecimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+
+	return d.Decimal.String(), nil
+}
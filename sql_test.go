@@ -0,0 +1,164 @@
+package decimal_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ sql.Scanner   = (*pgxdecimal.Decimal)(nil)
+	_ driver.Valuer = pgxdecimal.Decimal{}
+	_ sql.Scanner   = (*pgxdecimal.NullDecimal)(nil)
+	_ driver.Valuer = pgxdecimal.NullDecimal{}
+)
+
+func TestSQLScanner(t *testing.T) {
+	for i, tt := range []struct {
+		src      interface{}
+		expected string
+	}{
+		{src: "12.50", expected: "12.5"},
+		{src: []byte("-0.000012345"), expected: "-0.000012345"},
+		{src: "1e-3", expected: "0.001"},
+		{src: int64(-9223372036854775808), expected: "-9223372036854775808"},
+		{src: float64(0.1), expected: "0.1"},
+		{src: "NaN"},
+		{src: []byte("Infinity")},
+		{src: " 12.50"},
+		{src: "12abc"},
+		{src: math.NaN()},
+		{src: math.Inf(-1)},
+		{src: true},
+		{src: int32(1)},
+	} {
+		var d pgxdecimal.Decimal
+		err := d.Scan(tt.src)
+		var nd pgxdecimal.NullDecimal
+		nullErr := nd.Scan(tt.src)
+
+		if tt.expected == "" {
+			require.Errorf(t, err, "%d", i)
+			require.Errorf(t, nullErr, "%d", i)
+			continue
+		}
+
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, decimal.RequireFromString(tt.expected).Equal(decimal.Decimal(d)), "%d: %v", i, d)
+		require.NoErrorf(t, nullErr, "%d", i)
+		require.Truef(t, nd.Valid && decimal.RequireFromString(tt.expected).Equal(nd.Decimal), "%d: %v", i, nd)
+	}
+
+	var d pgxdecimal.Decimal
+	require.Error(t, d.Scan(nil))
+
+	nd := pgxdecimal.NullDecimal{Decimal: decimal.NewFromInt(1), Valid: true}
+	require.NoError(t, nd.Scan(nil))
+	require.False(t, nd.Valid)
+}
+
+func TestSQLValuer(t *testing.T) {
+	v, err := pgxdecimal.Decimal(decimal.RequireFromString("-123456.000123456")).Value()
+	require.NoError(t, err)
+	require.Equal(t, "-123456.000123456", v)
+
+	v, err = pgxdecimal.NullDecimal{Decimal: decimal.RequireFromString("1e3"), Valid: true}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "1000", v)
+
+	v, err = pgxdecimal.NullDecimal{}.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func isExpectedEqSQLNullDecimal(a sql.Null[decimal.Decimal]) func(interface{}) bool {
+	return func(v interface{}) bool {
+		b := v.(sql.Null[decimal.Decimal])
+		return a.Valid == b.Valid && a.V.Equal(b.V)
+	}
+}
+
+func TestValueRoundTripSQLNull(t *testing.T) {
+	for _, pgTypeName := range []string{"numeric", "float8", "int8"} {
+		pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, pgTypeName, []pgxtest.ValueRoundTripTest{
+			{
+				Param:  sql.Null[decimal.Decimal]{V: decimal.RequireFromString("-42"), Valid: true},
+				Result: new(sql.Null[decimal.Decimal]),
+				Test:   isExpectedEqSQLNullDecimal(sql.Null[decimal.Decimal]{V: decimal.RequireFromString("-42"), Valid: true}),
+			},
+			{
+				Param:  &sql.Null[decimal.Decimal]{V: decimal.RequireFromString("123456"), Valid: true},
+				Result: new(sql.Null[decimal.Decimal]),
+				Test:   isExpectedEqSQLNullDecimal(sql.Null[decimal.Decimal]{V: decimal.RequireFromString("123456"), Valid: true}),
+			},
+			{
+				Param:  sql.Null[decimal.Decimal]{},
+				Result: new(sql.Null[decimal.Decimal]),
+				Test:   isExpectedEqSQLNullDecimal(sql.Null[decimal.Decimal]{}),
+			},
+		})
+	}
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "numeric[]", []pgxtest.ValueRoundTripTest{
+		{
+			Param: []sql.Null[decimal.Decimal]{
+				{V: decimal.RequireFromString("-123456.000123456"), Valid: true},
+				{},
+			},
+			Result: new([]sql.Null[decimal.Decimal]),
+			Test: func(a interface{}) bool {
+				b := a.([]sql.Null[decimal.Decimal])
+				return len(b) == 2 &&
+					isExpectedEqSQLNullDecimal(sql.Null[decimal.Decimal]{V: decimal.RequireFromString("-123456.000123456"), Valid: true})(b[0]) &&
+					isExpectedEqSQLNullDecimal(sql.Null[decimal.Decimal]{})(b[1])
+			},
+		},
+	})
+}
+
+func TestSQLNull(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{StrictFloat: true})
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		for i, oid := range []uint32{pgtype.NumericOID, pgtype.Float8OID, pgtype.Int8OID} {
+			n := sql.Null[decimal.Decimal]{V: decimal.RequireFromString("12"), Valid: true}
+			buf, err := m.Encode(oid, format, n, nil)
+			require.NoErrorf(t, err, "%d", i)
+
+			var result sql.Null[decimal.Decimal]
+			if oid == pgtype.Float8OID {
+				// Options apply to sql.Null[decimal.Decimal] like decimal.NullDecimal.
+				err = m.Scan(oid, format, buf, &result)
+				var floatScanErr *pgxdecimal.FloatScanError
+				require.Truef(t, errors.As(err, &floatScanErr), "%d: %v", i, err)
+				continue
+			}
+
+			err = m.Scan(oid, format, buf, &result)
+			require.NoErrorf(t, err, "%d", i)
+			require.Truef(t, result.Valid && result.V.Equal(n.V), "%d: %v", i, result)
+
+			buf, err = m.Encode(oid, format, sql.Null[decimal.Decimal]{}, nil)
+			require.NoErrorf(t, err, "%d", i)
+			require.Nilf(t, buf, "%d", i)
+
+			err = m.Scan(oid, format, nil, &result)
+			require.NoErrorf(t, err, "%d", i)
+			require.Falsef(t, result.Valid, "%d", i)
+		}
+	}
+
+	n := sql.Null[decimal.Decimal]{V: decimal.RequireFromString("12.5"), Valid: true}
+	_, err := m.PlanEncode(pgtype.Int8OID, pgtype.BinaryFormatCode, n).Encode(n, nil)
+	require.Error(t, err)
+}
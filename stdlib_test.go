@@ -0,0 +1,114 @@
+package decimal_test
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func openDB(t testing.TB) *sql.DB {
+	config, err := pgx.ParseConfig("")
+	require.NoError(t, err)
+
+	db := stdlib.OpenDB(*config, stdlib.OptionAfterConnect(func(ctx context.Context, conn *pgx.Conn) error {
+		pgxdecimal.Register(conn.TypeMap())
+		return nil
+	}))
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
+
+func TestStdlibScan(t *testing.T) {
+	db := openDB(t)
+
+	var d decimal.Decimal
+	var nd decimal.NullDecimal
+	var wd pgxdecimal.Decimal
+	var wnd pgxdecimal.NullDecimal
+	err := db.QueryRow(`select 12.50::numeric, null::numeric, -123456789012345678901234567890.5::numeric, 42::numeric`).Scan(&d, &nd, &wd, &wnd)
+	require.NoError(t, err)
+	require.True(t, d.Equal(decimal.RequireFromString("12.5")), d)
+	require.False(t, nd.Valid)
+	require.True(t, decimal.Decimal(wd).Equal(decimal.RequireFromString("-123456789012345678901234567890.5")), wd)
+	require.True(t, wnd.Valid && wnd.Decimal.Equal(decimal.NewFromInt(42)), wnd)
+
+	err = db.QueryRow(`select 'NaN'::numeric`).Scan(&wd)
+	require.Error(t, err)
+}
+
+func TestStdlibArgs(t *testing.T) {
+	db := openDB(t)
+
+	for i, arg := range []interface{}{
+		decimal.RequireFromString("-123456789012345678901234567890.5"),
+		decimal.NullDecimal{Decimal: decimal.RequireFromString("0.000012345"), Valid: true},
+		pgxdecimal.Decimal(decimal.RequireFromString("1e20")),
+		pgxdecimal.NullDecimal{Decimal: decimal.RequireFromString("12.50"), Valid: true},
+	} {
+		var s string
+		err := db.QueryRow(`select $1::numeric::text`, arg).Scan(&s)
+		require.NoErrorf(t, err, "%d", i)
+
+		var d decimal.Decimal
+		err = db.QueryRow(`select $1::numeric`, arg).Scan(&d)
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, decimal.RequireFromString(s).Equal(d), "%d: %v", i, d)
+	}
+
+	var nd decimal.NullDecimal
+	err := db.QueryRow(`select $1::numeric`, pgxdecimal.NullDecimal{}).Scan(&nd)
+	require.NoError(t, err)
+	require.False(t, nd.Valid)
+}
+
+// srcRecorder is a sql.Scanner that records the value it is given.
+type srcRecorder struct {
+	src interface{}
+}
+
+func (r *srcRecorder) Scan(src interface{}) error {
+	r.src = src
+	return nil
+}
+
+func TestNumericSQLScanner(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	for i, tt := range []struct {
+		value    pgtype.Numeric
+		text     []byte
+		expected interface{}
+	}{
+		{value: pgtype.Numeric{Int: big.NewInt(42), Valid: true}, text: []byte("42"), expected: "42"},
+		{value: pgtype.Numeric{Int: big.NewInt(1250), Exp: -2, Valid: true}, text: []byte("12.50"), expected: "12.50"},
+		{value: pgtype.Numeric{Int: new(big.Int).Lsh(big.NewInt(1), 70), Valid: true}, text: []byte("1180591620717411303424"), expected: "1180591620717411303424"},
+		{value: pgtype.Numeric{NaN: true, Valid: true}, text: []byte("NaN"), expected: "NaN"},
+		{value: pgtype.Numeric{}, expected: nil},
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			src := tt.text
+			if format == pgtype.BinaryFormatCode {
+				var err error
+				src, err = m.Encode(pgtype.NumericOID, format, tt.value, nil)
+				require.NoErrorf(t, err, "%d", i)
+			}
+
+			var r srcRecorder
+			err := m.Scan(pgtype.NumericOID, format, src, &r)
+			require.NoErrorf(t, err, "%d", i)
+			require.Equalf(t, tt.expected, r.src, "%d", i)
+		}
+	}
+}
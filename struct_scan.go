@@ -0,0 +1,192 @@
+package decimal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// structTagKey is the struct tag used to configure how decimal fields are scanned by ScanStructByName.
+const structTagKey = "pgxdecimal"
+
+// ScanStructByName scans the current row of rows into the struct pointed to by dst. Columns are matched to exported
+// fields by the db struct tag or, if there is no tag, by case-insensitive field name. A field tagged db:"-" is ignored.
+// Every column must match a field and every field must match a column.
+//
+// decimal.Decimal and decimal.NullDecimal fields may use the pgxdecimal struct tag to control how they are scanned. The
+// tag is a comma separated list of options:
+//
+//	scale=N    round the scanned value to N decimal places
+//	round=MODE rounding mode used with scale; one of the RoundingMode names (default half_away_from_zero)
+//	null=zero  scan NULL as zero instead of failing (decimal.Decimal) or producing an invalid value (decimal.NullDecimal)
+//	nan=null   scan NaN as NULL instead of failing
+//
+// For example:
+//
+//	type Payment struct {
+//		Amount decimal.Decimal     `db:"amount" pgxdecimal:"scale=2,round=half_even"`
+//		Rate   decimal.NullDecimal `pgxdecimal:"scale=6,nan=null"`
+//	}
+//
+// The pgxdecimal struct tag is also applied when a composite value is scanned into a struct.
+func ScanStructByName(rows pgx.Rows, dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	fields, err := structScanFields(dstValue.Elem())
+	if err != nil {
+		return err
+	}
+
+	fieldDescriptions := rows.FieldDescriptions()
+	scanTargets := make([]interface{}, len(fieldDescriptions))
+	used := make([]bool, len(fields))
+
+	for i, fd := range fieldDescriptions {
+		columnName := string(fd.Name)
+		fieldIdx := -1
+		for j, f := range fields {
+			if f.name == columnName || (!f.tagged && strings.EqualFold(f.name, columnName)) {
+				fieldIdx = j
+				break
+			}
+		}
+		if fieldIdx == -1 {
+			return fmt.Errorf("cannot find field for column %s in %T", columnName, dst)
+		}
+		if used[fieldIdx] {
+			return fmt.Errorf("field %s matches more than one column", fields[fieldIdx].name)
+		}
+		used[fieldIdx] = true
+
+		scanTargets[i] = fields[fieldIdx].scanTarget
+	}
+
+	for i, f := range fields {
+		if !used[i] {
+			return fmt.Errorf("cannot find column for field %s in %T", f.name, dst)
+		}
+	}
+
+	return rows.Scan(scanTargets...)
+}
+
+type structScanField struct {
+	name       string
+	tagged     bool
+	scanTarget interface{}
+}
+
+func structScanFields(structValue reflect.Value) ([]structScanField, error) {
+	var fields []structScanField
+
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			embeddedFields, err := structScanFields(structValue.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embeddedFields...)
+			continue
+		}
+
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		dbTag, tagged := sf.Tag.Lookup("db")
+		if dbTag == "-" {
+			continue
+		}
+
+		f := structScanField{name: sf.Name, scanTarget: structValue.Field(i).Addr().Interface()}
+		if tagged {
+			f.name = dbTag
+			f.tagged = true
+		}
+
+		if policyTag, ok := sf.Tag.Lookup(structTagKey); ok {
+			policy, err := parseScanPolicyTag(policyTag)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+
+			f.scanTarget, err = newPolicyScanner(f.scanTarget, policy)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+func parseScanPolicyTag(tag string) (scanPolicy, error) {
+	var policy scanPolicy
+	var hasRounding bool
+
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		key, value := option, ""
+		if i := strings.IndexByte(option, '='); i >= 0 {
+			key, value = option[:i], option[i+1:]
+		}
+
+		switch key {
+		case "scale":
+			scale, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return scanPolicy{}, fmt.Errorf("invalid scale %q", value)
+			}
+			policy.scale = int32(scale)
+			policy.hasScale = true
+		case "round":
+			rounding, err := ParseRoundingMode(value)
+			if err != nil {
+				return scanPolicy{}, err
+			}
+			policy.rounding = rounding
+			hasRounding = true
+		case "null":
+			switch value {
+			case "error":
+				policy.nullAsZero = false
+			case "zero":
+				policy.nullAsZero = true
+			default:
+				return scanPolicy{}, fmt.Errorf("invalid null policy %q", value)
+			}
+		case "nan":
+			switch value {
+			case "error":
+				policy.nanAsNull = false
+			case "null":
+				policy.nanAsNull = true
+			default:
+				return scanPolicy{}, fmt.Errorf("invalid nan policy %q", value)
+			}
+		default:
+			return scanPolicy{}, fmt.Errorf("unknown %s option %q", structTagKey, key)
+		}
+	}
+
+	if hasRounding && !policy.hasScale {
+		return scanPolicy{}, fmt.Errorf("round requires scale")
+	}
+
+	return policy, nil
+}
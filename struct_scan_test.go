@@ -0,0 +1,126 @@
+package decimal_test
+
+import (
+	"context"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanStructByName(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		type Payment struct {
+			ID       int64
+			Amount   decimal.Decimal     `pgxdecimal:"scale=2,round=half_even"`
+			Rate     decimal.Decimal     `db:"interest_rate" pgxdecimal:"scale=6"`
+			Fee      decimal.Decimal     `pgxdecimal:"null=zero"`
+			Discount decimal.NullDecimal `pgxdecimal:"nan=null,scale=1,round=floor"`
+			Ignored  string              `db:"-"`
+		}
+
+		rows, err := conn.Query(ctx, `select 1::int8 as id, 2.125::numeric as amount, 0.0512345678::float8 as interest_rate, null::numeric as fee, 'NaN'::numeric as discount
+union all
+select 2, 2.135, 3, 0.5, -1.25`)
+		require.NoError(t, err)
+
+		var payments []Payment
+		for rows.Next() {
+			var p Payment
+			err := pgxdecimal.ScanStructByName(rows, &p)
+			require.NoError(t, err)
+			payments = append(payments, p)
+		}
+		require.NoError(t, rows.Err())
+
+		require.Len(t, payments, 2)
+
+		require.EqualValues(t, 1, payments[0].ID)
+		require.Equal(t, "2.12", payments[0].Amount.String())
+		require.Equal(t, "0.051235", payments[0].Rate.String())
+		require.True(t, payments[0].Fee.IsZero())
+		require.False(t, payments[0].Discount.Valid)
+
+		require.EqualValues(t, 2, payments[1].ID)
+		require.Equal(t, "2.14", payments[1].Amount.String())
+		require.Equal(t, "3", payments[1].Rate.String())
+		require.Equal(t, "0.5", payments[1].Fee.String())
+		require.True(t, payments[1].Discount.Valid)
+		require.Equal(t, "-1.3", payments[1].Discount.Decimal.String())
+	})
+}
+
+func TestScanStructByNameErrors(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		for i, tt := range []struct {
+			dst         interface{}
+			expectedErr string
+		}{
+			{
+				dst: &struct {
+					Amount decimal.Decimal `pgxdecimal:"round=half_even"`
+				}{},
+				expectedErr: "field Amount: round requires scale",
+			},
+			{
+				dst: &struct {
+					Amount decimal.Decimal `pgxdecimal:"scale=2,round=sideways"`
+				}{},
+				expectedErr: `field Amount: unknown rounding mode "sideways"`,
+			},
+			{
+				dst: &struct {
+					Amount float64 `pgxdecimal:"scale=2"`
+				}{},
+				expectedErr: "field Amount: cannot apply decimal scan policy to *float64",
+			},
+			{
+				dst: &struct {
+					Amount decimal.Decimal
+					Extra  decimal.Decimal
+				}{},
+				expectedErr: "cannot find column for field Extra",
+			},
+			{
+				dst: &struct {
+					Amount decimal.Decimal `db:"AMOUNT"`
+				}{},
+				expectedErr: "cannot find field for column amount",
+			},
+			{
+				dst: &struct {
+					AMOUNT decimal.Decimal
+				}{},
+				expectedErr: "",
+			},
+		} {
+			func() {
+				rows, err := conn.Query(ctx, `select 1.5::numeric as amount`)
+				require.NoError(t, err)
+				defer rows.Close()
+
+				require.True(t, rows.Next())
+				err = pgxdecimal.ScanStructByName(rows, tt.dst)
+				if tt.expectedErr == "" {
+					require.NoErrorf(t, err, "%d", i)
+				} else {
+					require.Errorf(t, err, "%d", i)
+					require.Containsf(t, err.Error(), tt.expectedErr, "%d", i)
+				}
+			}()
+		}
+
+		rows, err := conn.Query(ctx, `select null::numeric as amount`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.True(t, rows.Next())
+		var dst struct {
+			Amount decimal.Decimal `pgxdecimal:"scale=2"`
+		}
+		err = pgxdecimal.ScanStructByName(rows, &dst)
+		require.EqualError(t, err, "can't scan into dest[0]: cannot scan NULL into *decimal.Decimal")
+	})
+}
@@ -0,0 +1,248 @@
+package decimal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// TextParsing controls how decimals are parsed from text. By default a decimal in text must be an optionally signed
+// number with an optional fraction and exponent, e.g. "-12.50" or "1.5e3", with nothing before or after it.
+type TextParsing struct {
+	// AllowSpace allows leading and trailing whitespace.
+	AllowSpace bool
+
+	// RejectExponent rejects exponent notation such as "1.5e3".
+	RejectExponent bool
+}
+
+// parse parses s as a decimal. NaN and infinity are not decimals and are rejected like any other invalid text.
+func (p TextParsing) parse(s string) (decimal.Decimal, error) {
+	text := s
+	if p.AllowSpace {
+		text = strings.TrimSpace(text)
+	}
+
+	if !isDecimalText(text, !p.RejectExponent) {
+		return decimal.Decimal{}, fmt.Errorf("invalid decimal text %q", s)
+	}
+
+	return decimal.NewFromString(text)
+}
+
+// isDecimalText reports whether s is an optionally signed number with an optional fraction and, if allowExponent, an
+// optional exponent.
+func isDecimalText(s string, allowExponent bool) bool {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+
+	digits := 0
+	for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+
+	if allowExponent && i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		expDigits := 0
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+			expDigits++
+		}
+		if expDigits == 0 {
+			return false
+		}
+	}
+
+	return i == len(s)
+}
+
+// scanText parses v, which must be valid. NaN and infinity are reported with the same errors as ScanNumeric rather
+// than as invalid text.
+func (p TextParsing) scanText(v pgtype.Text, typeName string) (decimal.Decimal, error) {
+	s := v.String
+	if p.AllowSpace {
+		s = strings.TrimSpace(s)
+	}
+
+	if strings.EqualFold(s, "NaN") {
+		return decimal.Decimal{}, fmt.Errorf("cannot scan NaN into %s", typeName)
+	}
+	switch strings.ToLower(strings.TrimLeft(s, "+-")) {
+	case "infinity", "inf":
+		return decimal.Decimal{}, fmt.Errorf("cannot scan %s into %s", s, typeName)
+	}
+
+	return p.parse(s)
+}
+
+// scanText scans a decimal from text with the default TextParsing.
+func (d *Decimal) scanText(s string) error {
+	dd, err := TextParsing{}.scanText(pgtype.Text{String: s, Valid: true}, "*decimal.Decimal")
+	if err != nil {
+		return err
+	}
+
+	*d = Decimal(dd)
+
+	return nil
+}
+
+// scanText scans a decimal from text with the default TextParsing.
+func (d *NullDecimal) scanText(s string) error {
+	dd, err := TextParsing{}.scanText(pgtype.Text{String: s, Valid: true}, "*decimal.NullDecimal")
+	if err != nil {
+		return err
+	}
+
+	*d = NullDecimal(decimal.NullDecimal{Decimal: dd, Valid: true})
+
+	return nil
+}
+
+// textCodec wraps the codec of a PostgreSQL text type so decimals are encoded as and parsed from text.
+// decimal.Decimal and decimal.NullDecimal are parsed subject to opts and Decimal and NullDecimal with the default
+// options. None of them implement pgtype.TextValuer or pgtype.TextScanner, because pgx would use those for every type
+// in the text format and bypass the numeric, float and integer conversions, so this is the only way they reach a text
+// column.
+type textCodec struct {
+	pgtype.Codec
+	opts *Options
+
+	// trimRight trims the padding of bpchar values.
+	trimRight bool
+}
+
+func registerTextCodecs(m *pgtype.Map, opts *Options) {
+	for _, t := range []struct {
+		oid       uint32
+		trimRight bool
+	}{
+		{oid: pgtype.TextOID},
+		{oid: pgtype.VarcharOID},
+		{oid: pgtype.BPCharOID, trimRight: true},
+	} {
+		dt, ok := m.TypeForOID(t.oid)
+		if !ok {
+			continue
+		}
+
+		codec := dt.Codec
+		if c, ok := codec.(*textCodec); ok {
+			codec = c.Codec
+		}
+
+		m.RegisterType(&pgtype.Type{
+			Name:  dt.Name,
+			OID:   dt.OID,
+			Codec: &textCodec{Codec: codec, opts: opts, trimRight: t.trimRight},
+		})
+	}
+}
+
+func (c *textCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value interface{}) pgtype.EncodePlan {
+	switch value.(type) {
+	case decimal.Decimal, decimal.NullDecimal, Decimal, NullDecimal:
+		next := c.Codec.PlanEncode(m, oid, format, pgtype.Text{})
+		if next == nil {
+			return nil
+		}
+		return &encodePlanDecimalToText{next: next}
+	}
+
+	return c.Codec.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanDecimalToText struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *encodePlanDecimalToText) Encode(value interface{}, buf []byte) (newBuf []byte, err error) {
+	var t pgtype.Text
+	switch value := value.(type) {
+	case decimal.Decimal:
+		t = pgtype.Text{String: value.String(), Valid: true}
+	case decimal.NullDecimal:
+		t = pgtype.Text{String: value.Decimal.String(), Valid: value.Valid}
+	case Decimal:
+		t = pgtype.Text{String: decimal.Decimal(value).String(), Valid: true}
+	case NullDecimal:
+		t = pgtype.Text{String: value.Decimal.String(), Valid: value.Valid}
+	}
+
+	return plan.next.Encode(t, buf)
+}
+
+func (c *textCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target interface{}) pgtype.ScanPlan {
+	switch target.(type) {
+	case *decimal.Decimal, *decimal.NullDecimal, *Decimal, *NullDecimal:
+		return &scanPlanTextToDecimal{codec: c}
+	}
+
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+type scanPlanTextToDecimal struct {
+	codec *textCodec
+}
+
+func (plan *scanPlanTextToDecimal) Scan(src []byte, dst interface{}) error {
+	var v pgtype.Text
+	if src != nil {
+		v = pgtype.Text{String: string(src), Valid: true}
+		if plan.codec.trimRight {
+			v.String = strings.TrimRight(v.String, " ")
+		}
+	}
+
+	switch dst := dst.(type) {
+	case *decimal.Decimal:
+		if !v.Valid {
+			return fmt.Errorf("cannot scan NULL into *decimal.Decimal")
+		}
+
+		d, err := plan.codec.opts.TextParsing.scanText(v, "*decimal.Decimal")
+		if err != nil {
+			return err
+		}
+		*dst = d
+	case *decimal.NullDecimal:
+		if !v.Valid {
+			*dst = decimal.NullDecimal{}
+			return nil
+		}
+
+		d, err := plan.codec.opts.TextParsing.scanText(v, "*decimal.NullDecimal")
+		if err != nil {
+			return err
+		}
+		*dst = decimal.NullDecimal{Decimal: d, Valid: true}
+	case *Decimal:
+		if !v.Valid {
+			return fmt.Errorf("cannot scan NULL into *decimal.Decimal")
+		}
+		return dst.scanText(v.String)
+	case *NullDecimal:
+		if !v.Valid {
+			*dst = NullDecimal{}
+			return nil
+		}
+		return dst.scanText(v.String)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueRoundTripText(t *testing.T) {
+	for _, pgTypeName := range []string{"text", "varchar"} {
+		pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, pgTypeName, []pgxtest.ValueRoundTripTest{
+			{
+				Param:  decimal.RequireFromString("1"),
+				Result: new(decimal.Decimal),
+				Test:   isExpectedEqDecimal(decimal.RequireFromString("1")),
+			},
+			{
+				Param:  decimal.RequireFromString("-123456.000123456"),
+				Result: new(decimal.Decimal),
+				Test:   isExpectedEqDecimal(decimal.RequireFromString("-123456.000123456")),
+			},
+			{
+				Param:  decimal.RequireFromString("1e40"),
+				Result: new(decimal.Decimal),
+				Test:   isExpectedEqDecimal(decimal.RequireFromString("1e40")),
+			},
+			{
+				Param:  pgxdecimal.Decimal(decimal.RequireFromString("0.000012345")),
+				Result: new(pgxdecimal.Decimal),
+				Test: func(a interface{}) bool {
+					return decimal.Decimal(*a.(*pgxdecimal.Decimal)).Equal(decimal.RequireFromString("0.000012345"))
+				},
+			},
+			{
+				Param:  decimal.NullDecimal{Decimal: decimal.RequireFromString("12.50"), Valid: true},
+				Result: new(decimal.NullDecimal),
+				Test:   isExpectedEqNullDecimal(decimal.NullDecimal{Decimal: decimal.RequireFromString("12.50"), Valid: true}),
+			},
+			{
+				Param:  decimal.NullDecimal{},
+				Result: new(decimal.NullDecimal),
+				Test:   isExpectedEqNullDecimal(decimal.NullDecimal{}),
+			},
+		})
+	}
+}
+
+func TestTextScan(t *testing.T) {
+	for i, tt := range []struct {
+		opts     pgxdecimal.Options
+		oid      uint32
+		src      string
+		expected string
+	}{
+		{oid: pgtype.TextOID, src: "12.50", expected: "12.5"},
+		{oid: pgtype.TextOID, src: "+.5", expected: "0.5"},
+		{oid: pgtype.TextOID, src: "-5.", expected: "-5"},
+		{oid: pgtype.TextOID, src: "1.5e3", expected: "1500"},
+		{oid: pgtype.VarcharOID, src: "-2E-2", expected: "-0.02"},
+		{oid: pgtype.BPCharOID, src: "12.50   ", expected: "12.5"},
+		{opts: pgxdecimal.Options{TextParsing: pgxdecimal.TextParsing{AllowSpace: true}}, oid: pgtype.TextOID, src: " \t12.50\n", expected: "12.5"},
+		{oid: pgtype.TextOID, src: ""},
+		{oid: pgtype.TextOID, src: " 12.50"},
+		{oid: pgtype.TextOID, src: "12.50 "},
+		{oid: pgtype.BPCharOID, src: " 12.50"},
+		{oid: pgtype.TextOID, src: "12abc"},
+		{oid: pgtype.TextOID, src: "1.2.3"},
+		{oid: pgtype.TextOID, src: "."},
+		{oid: pgtype.TextOID, src: "-"},
+		{oid: pgtype.TextOID, src: "1e"},
+		{oid: pgtype.TextOID, src: "e5"},
+		{oid: pgtype.TextOID, src: "1_000"},
+		{oid: pgtype.TextOID, src: "0x10"},
+		{oid: pgtype.TextOID, src: "NaN"},
+		{oid: pgtype.TextOID, src: "-Infinity"},
+		{opts: pgxdecimal.Options{TextParsing: pgxdecimal.TextParsing{RejectExponent: true}}, oid: pgtype.TextOID, src: "1.5e3"},
+	} {
+		m := pgtype.NewMap()
+		pgxdecimal.RegisterWithOptions(m, tt.opts)
+
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			var d decimal.Decimal
+			err := m.Scan(tt.oid, format, []byte(tt.src), &d)
+			var nd decimal.NullDecimal
+			nullErr := m.Scan(tt.oid, format, []byte(tt.src), &nd)
+
+			if tt.expected == "" {
+				require.Errorf(t, err, "%d: %v", i, d)
+				require.Errorf(t, nullErr, "%d: %v", i, nd)
+				continue
+			}
+
+			require.NoErrorf(t, err, "%d", i)
+			require.Truef(t, decimal.RequireFromString(tt.expected).Equal(d), "%d: %v", i, d)
+			require.NoErrorf(t, nullErr, "%d", i)
+			require.Truef(t, nd.Valid && decimal.RequireFromString(tt.expected).Equal(nd.Decimal), "%d: %v", i, nd)
+		}
+	}
+
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	var nd decimal.NullDecimal
+	err := m.Scan(pgtype.TextOID, pgtype.TextFormatCode, nil, &nd)
+	require.NoError(t, err)
+	require.False(t, nd.Valid)
+
+	var d decimal.Decimal
+	err = m.Scan(pgtype.TextOID, pgtype.TextFormatCode, nil, &d)
+	require.Error(t, err)
+}
+
+func TestTextScanner(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.Register(m)
+
+	// Decimal and NullDecimal are parsed from text columns with the default options and from the text format of other
+	// types by their codecs.
+	for i, tt := range []struct {
+		oid      uint32
+		src      string
+		expected string
+	}{
+		{oid: pgtype.TextOID, src: "12.50", expected: "12.5"},
+		{oid: pgtype.NumericOID, src: "-123456.000123456", expected: "-123456.000123456"},
+		{oid: pgtype.Float8OID, src: "1e+20", expected: "1e20"},
+		{oid: pgtype.Int8OID, src: "-9223372036854775808", expected: "-9223372036854775808"},
+		{oid: pgtype.NumericOID, src: "NaN"},
+		{oid: pgtype.Float8OID, src: "-Infinity"},
+		{oid: pgtype.TextOID, src: " 12.50"},
+	} {
+		var d pgxdecimal.Decimal
+		err := m.Scan(tt.oid, pgtype.TextFormatCode, []byte(tt.src), &d)
+		var nd pgxdecimal.NullDecimal
+		nullErr := m.Scan(tt.oid, pgtype.TextFormatCode, []byte(tt.src), &nd)
+
+		if tt.expected == "" {
+			require.Errorf(t, err, "%d", i)
+			require.Errorf(t, nullErr, "%d", i)
+			continue
+		}
+
+		require.NoErrorf(t, err, "%d", i)
+		require.Truef(t, decimal.RequireFromString(tt.expected).Equal(decimal.Decimal(d)), "%d: %v", i, d)
+		require.NoErrorf(t, nullErr, "%d", i)
+		require.Truef(t, nd.Valid && decimal.RequireFromString(tt.expected).Equal(nd.Decimal), "%d: %v", i, nd)
+	}
+}
+
+func TestTextEncode(t *testing.T) {
+	m := pgtype.NewMap()
+	pgxdecimal.RegisterWithOptions(m, pgxdecimal.Options{StrictFloat: true})
+
+	for i, tt := range []struct {
+		oid      uint32
+		value    interface{}
+		expected string
+	}{
+		{oid: pgtype.TextOID, value: decimal.RequireFromString("12.50"), expected: "12.5"},
+		{oid: pgtype.VarcharOID, value: decimal.RequireFromString("1e3"), expected: "1000"},
+		{oid: pgtype.BPCharOID, value: decimal.NullDecimal{Decimal: decimal.RequireFromString("-0.01"), Valid: true}, expected: "-0.01"},
+		{oid: pgtype.TextOID, value: pgxdecimal.Decimal(decimal.RequireFromString("0.1")), expected: "0.1"},
+		{oid: pgtype.TextOID, value: pgxdecimal.NullDecimal{Decimal: decimal.RequireFromString("0.1"), Valid: true}, expected: "0.1"},
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(tt.oid, format, tt.value, nil)
+			require.NoErrorf(t, err, "%d", i)
+			require.Equalf(t, tt.expected, string(buf), "%d", i)
+		}
+	}
+
+	buf, err := m.Encode(pgtype.TextOID, pgtype.TextFormatCode, decimal.NullDecimal{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+
+	// decimal.Decimal still uses the float and integer conversions in the text format.
+	value := decimal.RequireFromString("0.10000000000000000001")
+	_, err = m.PlanEncode(pgtype.Float8OID, pgtype.TextFormatCode, value).Encode(value, nil)
+	var inexactErr *pgxdecimal.InexactFloatError
+	require.True(t, errors.As(err, &inexactErr), err)
+
+	var d decimal.Decimal
+	err = m.Scan(pgtype.Float8OID, pgtype.TextFormatCode, []byte("0.1"), &d)
+	var floatScanErr *pgxdecimal.FloatScanError
+	require.True(t, errors.As(err, &floatScanErr), err)
+}
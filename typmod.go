@@ -0,0 +1,64 @@
+package decimal
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// varhdrsz is the PostgreSQL VARHDRSZ constant that is added to every numeric type modifier.
+const varhdrsz = 4
+
+// NumericTypmod is the precision and scale of a numeric(precision, scale) type. As of PostgreSQL 15 the scale may be
+// negative (e.g. numeric(5, -3) rounds to thousands) or greater than the precision.
+type NumericTypmod struct {
+	Precision int32
+	Scale     int32
+}
+
+// ParseNumericTypmod parses a numeric type modifier as found in pg_attribute.atttypmod or
+// pgproto3.FieldDescription.TypeModifier. ok is false if typmod does not constrain the numeric (i.e. plain numeric).
+func ParseNumericTypmod(typmod int32) (t NumericTypmod, ok bool) {
+	if typmod < varhdrsz {
+		return NumericTypmod{}, false
+	}
+
+	typmod -= varhdrsz
+	precision := (typmod >> 16) & 0xffff
+	// The scale is stored as an 11-bit two's complement integer.
+	scale := ((typmod & 0x7ff) ^ 1024) - 1024
+
+	return NumericTypmod{Precision: precision, Scale: scale}, true
+}
+
+// Typmod returns t encoded as a PostgreSQL type modifier.
+func (t NumericTypmod) Typmod() int32 {
+	return ((t.Precision << 16) | (t.Scale & 0x7ff)) + varhdrsz
+}
+
+func (t NumericTypmod) String() string {
+	return fmt.Sprintf("numeric(%d,%d)", t.Precision, t.Scale)
+}
+
+// Round rounds d to t.Scale the same way PostgreSQL does when a value is stored in a column of type t. Ties are rounded
+// away from zero. A *NumericFieldOverflowError is returned if the rounded value does not fit in t.Precision.
+func (t NumericTypmod) Round(d decimal.Decimal) (decimal.Decimal, error) {
+	rounded := d.Round(t.Scale)
+
+	if !rounded.IsZero() && rounded.Abs().Cmp(decimal.New(1, t.Precision-t.Scale)) >= 0 {
+		return decimal.Decimal{}, &NumericFieldOverflowError{Value: d, Typmod: t}
+	}
+
+	return rounded, nil
+}
+
+// NumericFieldOverflowError is returned when a value does not fit in a numeric(precision, scale) type. It is the client
+// side equivalent of the PostgreSQL "numeric field overflow" error.
+type NumericFieldOverflowError struct {
+	Value  decimal.Decimal
+	Typmod NumericTypmod
+}
+
+func (e *NumericFieldOverflowError) Error() string {
+	return fmt.Sprintf("numeric field overflow: %v does not fit in %v", e.Value, e.Typmod)
+}
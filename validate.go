@@ -0,0 +1,264 @@
+package decimal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// Querier is the interface used to load catalog information. It is implemented by *pgx.Conn, pgx.Tx and
+// *pgxpool.Pool.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// NumericColumn is a numeric column of a table and its type modifier.
+type NumericColumn struct {
+	Name string
+
+	// Typmod is only meaningful if Constrained is true. A plain numeric column is not constrained.
+	Typmod      NumericTypmod
+	Constrained bool
+}
+
+// ColumnValidationError is returned when the value for Column is not valid for that column.
+type ColumnValidationError struct {
+	Column string
+	Err    error
+}
+
+func (e *ColumnValidationError) Error() string {
+	return fmt.Sprintf("column %s: %v", e.Column, e.Err)
+}
+
+func (e *ColumnValidationError) Unwrap() error {
+	return e.Err
+}
+
+// RowValidationError is returned by NumericValidator.ValidateRow when one or more columns are not valid.
+type RowValidationError struct {
+	Errors []*ColumnValidationError
+}
+
+func (e *RowValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// NumericValidator checks decimal values against the precision and scale of numeric columns before they are sent to
+// the server. The numeric columns of each table are loaded from pg_attribute on first use and cached until invalidated.
+// It is safe for concurrent use.
+type NumericValidator struct {
+	mu     sync.Mutex
+	tables map[string]map[string]NumericColumn
+}
+
+// NewNumericValidator returns a new NumericValidator with an empty cache.
+func NewNumericValidator() *NumericValidator {
+	return &NumericValidator{tables: make(map[string]map[string]NumericColumn)}
+}
+
+const loadNumericColumnsSQL = `select attname, atttypmod
+from pg_attribute
+where attrelid = $1::regclass
+  and attnum > 0
+  and not attisdropped
+  and atttypid = 'numeric'::regtype`
+
+// NumericColumns returns the numeric columns of table keyed by column name. The columns are cached but the returned map
+// is a copy that the caller may modify.
+func (v *NumericValidator) NumericColumns(ctx context.Context, q Querier, table pgx.Identifier) (map[string]NumericColumn, error) {
+	columns, err := v.numericColumns(ctx, q, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return maps.Clone(columns), nil
+}
+
+// numericColumns returns the cached numeric columns of table, loading them if needed. The result must not be modified.
+func (v *NumericValidator) numericColumns(ctx context.Context, q Querier, table pgx.Identifier) (map[string]NumericColumn, error) {
+	key := table.Sanitize()
+
+	v.mu.Lock()
+	columns, ok := v.tables[key]
+	v.mu.Unlock()
+	if ok {
+		return columns, nil
+	}
+
+	rows, err := q.Query(ctx, loadNumericColumnsSQL, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns = make(map[string]NumericColumn)
+	for rows.Next() {
+		var name string
+		var typmod int32
+		err := rows.Scan(&name, &typmod)
+		if err != nil {
+			return nil, err
+		}
+
+		column := NumericColumn{Name: name}
+		column.Typmod, column.Constrained = ParseNumericTypmod(typmod)
+		columns[name] = column
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	v.mu.Lock()
+	v.tables[key] = columns
+	v.mu.Unlock()
+
+	return columns, nil
+}
+
+// Invalidate removes table from the cache. It should be called after the table is altered.
+func (v *NumericValidator) Invalidate(table pgx.Identifier) {
+	v.mu.Lock()
+	delete(v.tables, table.Sanitize())
+	v.mu.Unlock()
+}
+
+// InvalidateAll empties the cache.
+func (v *NumericValidator) InvalidateAll() {
+	v.mu.Lock()
+	v.tables = make(map[string]map[string]NumericColumn)
+	v.mu.Unlock()
+}
+
+// ValidateRow checks the values in row against the numeric columns of table. columnNames and row are positional in the
+// same way as the arguments to pgx.Conn.CopyFrom. Values that are not decimal.Decimal, decimal.NullDecimal, Decimal,
+// NullDecimal or pointers to them are ignored as are columns that are not numeric. If any values are invalid a
+// *RowValidationError is returned.
+func (v *NumericValidator) ValidateRow(ctx context.Context, q Querier, table pgx.Identifier, columnNames []string, row []interface{}) error {
+	columns, err := v.numericColumns(ctx, q, table)
+	if err != nil {
+		return err
+	}
+
+	return validateRow(columns, columnNames, row)
+}
+
+func validateRow(columns map[string]NumericColumn, columnNames []string, row []interface{}) error {
+	if len(columnNames) != len(row) {
+		return fmt.Errorf("expected %d values, got %d values", len(columnNames), len(row))
+	}
+
+	var rowErr *RowValidationError
+	for i, name := range columnNames {
+		column, ok := columns[name]
+		if !ok || !column.Constrained {
+			continue
+		}
+
+		d, ok := decimalFromValue(row[i])
+		if !ok {
+			continue
+		}
+
+		_, err := column.Typmod.Round(d)
+		if err != nil {
+			if rowErr == nil {
+				rowErr = &RowValidationError{}
+			}
+			rowErr.Errors = append(rowErr.Errors, &ColumnValidationError{Column: name, Err: err})
+		}
+	}
+
+	if rowErr != nil {
+		return rowErr
+	}
+
+	return nil
+}
+
+// decimalFromValue returns the decimal in value. ok is false if value is not a decimal or is NULL.
+func decimalFromValue(value interface{}) (d decimal.Decimal, ok bool) {
+	switch value := value.(type) {
+	case decimal.Decimal:
+		return value, true
+	case *decimal.Decimal:
+		if value != nil {
+			return *value, true
+		}
+	case decimal.NullDecimal:
+		return value.Decimal, value.Valid
+	case *decimal.NullDecimal:
+		if value != nil {
+			return value.Decimal, value.Valid
+		}
+	case Decimal:
+		return decimal.Decimal(value), true
+	case *Decimal:
+		if value != nil {
+			return decimal.Decimal(*value), true
+		}
+	case NullDecimal:
+		return value.Decimal, value.Valid
+	case *NullDecimal:
+		if value != nil {
+			return value.Decimal, value.Valid
+		}
+	case sql.Null[decimal.Decimal]:
+		return value.V, value.Valid
+	case *sql.Null[decimal.Decimal]:
+		if value != nil {
+			return value.V, value.Valid
+		}
+	}
+
+	return decimal.Decimal{}, false
+}
+
+// CopyFromSource returns a pgx.CopyFromSource that validates each row of src with ValidateRow before it is returned.
+// The numeric columns of table are loaded before CopyFromSource returns.
+func (v *NumericValidator) CopyFromSource(ctx context.Context, q Querier, table pgx.Identifier, columnNames []string, src pgx.CopyFromSource) (pgx.CopyFromSource, error) {
+	columns, err := v.numericColumns(ctx, q, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validatingCopyFromSource{columns: columns, columnNames: columnNames, src: src}, nil
+}
+
+type validatingCopyFromSource struct {
+	columns     map[string]NumericColumn
+	columnNames []string
+	src         pgx.CopyFromSource
+}
+
+func (s *validatingCopyFromSource) Next() bool {
+	return s.src.Next()
+}
+
+func (s *validatingCopyFromSource) Values() ([]interface{}, error) {
+	row, err := s.src.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateRow(s.columns, s.columnNames, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+func (s *validatingCopyFromSource) Err() error {
+	return s.src.Err()
+}
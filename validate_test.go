@@ -0,0 +1,121 @@
+package decimal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericValidatorValidateRow(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, `create temporary table pgxdecimal_validate (
+	id int8,
+	amount numeric(5,2),
+	rate numeric(10,6),
+	unconstrained numeric
+)`)
+		require.NoError(t, err)
+
+		table := pgx.Identifier{"pgxdecimal_validate"}
+		columnNames := []string{"id", "amount", "rate", "unconstrained"}
+		validator := pgxdecimal.NewNumericValidator()
+
+		columns, err := validator.NumericColumns(ctx, conn, table)
+		require.NoError(t, err)
+		require.Len(t, columns, 3)
+		require.Equal(t, pgxdecimal.NumericTypmod{Precision: 5, Scale: 2}, columns["amount"].Typmod)
+		require.False(t, columns["unconstrained"].Constrained)
+
+		// The result is a copy of the cache.
+		delete(columns, "amount")
+		columns, err = validator.NumericColumns(ctx, conn, table)
+		require.NoError(t, err)
+		require.Len(t, columns, 3)
+
+		err = validator.ValidateRow(ctx, conn, table, columnNames, []interface{}{
+			int64(1),
+			decimal.RequireFromString("999.994"),
+			decimal.NullDecimal{},
+			decimal.RequireFromString("123456789012345678901234567890"),
+		})
+		require.NoError(t, err)
+
+		err = validator.ValidateRow(ctx, conn, table, columnNames, []interface{}{
+			int64(1),
+			decimal.RequireFromString("999.995"),
+			&decimal.NullDecimal{Decimal: decimal.RequireFromString("10000"), Valid: true},
+			decimal.Zero,
+		})
+		var rowErr *pgxdecimal.RowValidationError
+		require.True(t, errors.As(err, &rowErr))
+		require.Len(t, rowErr.Errors, 2)
+		require.Equal(t, "amount", rowErr.Errors[0].Column)
+		require.Equal(t, "rate", rowErr.Errors[1].Column)
+
+		var overflowErr *pgxdecimal.NumericFieldOverflowError
+		require.True(t, errors.As(rowErr.Errors[0], &overflowErr))
+		require.Equal(t, pgxdecimal.NumericTypmod{Precision: 5, Scale: 2}, overflowErr.Typmod)
+
+		// The server agrees with the validator.
+		_, err = conn.Exec(ctx, `insert into pgxdecimal_validate (amount) values ($1)`, decimal.RequireFromString("999.995"))
+		require.Error(t, err)
+	})
+}
+
+func TestNumericValidatorInvalidate(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, `create temporary table pgxdecimal_validate (amount numeric(5,2))`)
+		require.NoError(t, err)
+
+		table := pgx.Identifier{"pgxdecimal_validate"}
+		columnNames := []string{"amount"}
+		row := []interface{}{decimal.RequireFromString("12345.67")}
+		validator := pgxdecimal.NewNumericValidator()
+
+		err = validator.ValidateRow(ctx, conn, table, columnNames, row)
+		require.Error(t, err)
+
+		_, err = conn.Exec(ctx, `alter table pgxdecimal_validate alter column amount type numeric(10,2)`)
+		require.NoError(t, err)
+
+		err = validator.ValidateRow(ctx, conn, table, columnNames, row)
+		require.Error(t, err, "stale cache entry should still be used")
+
+		validator.Invalidate(table)
+
+		err = validator.ValidateRow(ctx, conn, table, columnNames, row)
+		require.NoError(t, err)
+	})
+}
+
+func TestNumericValidatorCopyFromSource(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, `create temporary table pgxdecimal_validate (id int8, amount numeric(5,2))`)
+		require.NoError(t, err)
+
+		table := pgx.Identifier{"pgxdecimal_validate"}
+		columnNames := []string{"id", "amount"}
+		validator := pgxdecimal.NewNumericValidator()
+
+		src, err := validator.CopyFromSource(ctx, conn, table, columnNames, pgx.CopyFromRows([][]interface{}{
+			{int64(1), decimal.RequireFromString("1.23")},
+			{int64(2), decimal.RequireFromString("1000")},
+		}))
+		require.NoError(t, err)
+
+		// The validation error is reported to the server as the reason the copy failed.
+		_, err = conn.CopyFrom(ctx, table, columnNames, src)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "column amount: numeric field overflow")
+
+		var n int64
+		err = conn.QueryRow(ctx, `select count(*) from pgxdecimal_validate`).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, n)
+	})
+}